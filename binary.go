@@ -0,0 +1,204 @@
+package gbuf
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/zalgonoise/cfg"
+)
+
+// MaxBinaryItems is the default maximum number of T items accepted when decoding
+// binary data with UnmarshalBinary, preventing corrupt or malicious length headers
+// from causing large allocations. It can be changed per call with WithMaxItems.
+const MaxBinaryItems = 1 << 24
+
+const (
+	bigEndianFlag    byte = 0
+	littleEndianFlag byte = 1
+)
+
+// BinaryConfig holds the settings for encoding and decoding T item slices in the
+// binary format used by MarshalBinary and UnmarshalBinary.
+type BinaryConfig struct {
+	order    binary.ByteOrder
+	maxItems int
+}
+
+func defaultBinaryConfig() BinaryConfig {
+	return BinaryConfig{
+		order:    binary.BigEndian,
+		maxItems: MaxBinaryItems,
+	}
+}
+
+// WithByteOrder sets the byte order used to encode T items. The default is big-endian.
+// Decoding always uses the byte order recorded in the data's header.
+func WithByteOrder(order binary.ByteOrder) cfg.Option[BinaryConfig] {
+	if order == nil {
+		return cfg.NoOp[BinaryConfig]{}
+	}
+
+	return cfg.Register(func(c BinaryConfig) BinaryConfig {
+		c.order = order
+
+		return c
+	})
+}
+
+// WithMaxItems sets the maximum number of T items accepted when decoding.
+func WithMaxItems(n int) cfg.Option[BinaryConfig] {
+	if n <= 0 {
+		return cfg.NoOp[BinaryConfig]{}
+	}
+
+	return cfg.Register(func(c BinaryConfig) BinaryConfig {
+		c.maxItems = n
+
+		return c
+	})
+}
+
+// MarshalBinary encodes items into a binary format, composed of a header with the
+// byte order and the number of T items, followed by the T items as written by
+// binary.Write. T must be a fixed-size type, as accepted by encoding/binary;
+// otherwise MarshalBinary returns ErrBinaryUnsupportedType.
+func MarshalBinary[T any](items []T, opts ...cfg.Option[BinaryConfig]) ([]byte, error) {
+	config := cfg.Set(defaultBinaryConfig(), opts...)
+
+	size := binary.Size(*new(T))
+	if size < 0 {
+		return nil, ErrBinaryUnsupportedType
+	}
+
+	flag := littleEndianFlag
+	if config.order.Uint16([]byte{0, 1}) == 1 {
+		flag = bigEndianFlag
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 1+binary.MaxVarintLen64+size*len(items)))
+	buf.WriteByte(flag)
+	buf.Write(binary.AppendUvarint(buf.AvailableBuffer(), uint64(len(items))))
+
+	if err := binary.Write(buf, config.order, items); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a T item slice from data, in the format produced by
+// MarshalBinary. The length header is validated against the maximum number of
+// T items (MaxBinaryItems by default) and against the length of data, before
+// any allocation takes place.
+func UnmarshalBinary[T any](data []byte, opts ...cfg.Option[BinaryConfig]) ([]T, error) {
+	items, err := decodeBinary[T](data, cfg.Set(defaultBinaryConfig(), opts...), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// decodeBinary decodes the T items in data, appending them to dst.
+func decodeBinary[T any](data []byte, config BinaryConfig, dst []T) ([]T, error) {
+	size := binary.Size(*new(T))
+	if size < 0 {
+		return dst, ErrBinaryUnsupportedType
+	}
+
+	if len(data) == 0 {
+		return dst, ErrBinaryInvalidHeader
+	}
+
+	var order binary.ByteOrder
+
+	switch data[0] {
+	case bigEndianFlag:
+		order = binary.BigEndian
+	case littleEndianFlag:
+		order = binary.LittleEndian
+	default:
+		return dst, ErrBinaryInvalidHeader
+	}
+
+	count, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return dst, ErrBinaryInvalidHeader
+	}
+
+	if count > uint64(config.maxItems) {
+		return dst, ErrBinaryTooLarge
+	}
+
+	data = data[1+n:]
+
+	// check the count against the data length before multiplying, as a large
+	// count could overflow into a matching length
+	if size == 0 && len(data) != 0 ||
+		size > 0 && (count > uint64(len(data))/uint64(size) || count*uint64(size) != uint64(len(data))) {
+		return dst, ErrBinaryInvalidLength
+	}
+
+	l := len(dst)
+	dst = append(dst, make([]T, count)...)
+
+	if err := binary.Read(bytes.NewReader(data), order, dst[l:]); err != nil {
+		return dst[:l], err
+	}
+
+	return dst, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, encoding the
+// unread portion of the buffer as described in the package-level MarshalBinary,
+// with the default options: big-endian byte order. To set the byte order with
+// WithByteOrder, use the package-level MarshalBinary on b.Value() instead.
+// T must be a fixed-size type.
+func (b *Buffer[T]) MarshalBinary() ([]byte, error) {
+	return MarshalBinary(b.buf[b.off:])
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, replacing
+// the contents of the buffer with the T items decoded from data, as described in
+// the package-level UnmarshalBinary. If data is invalid, the buffer is left
+// unchanged. T must be a fixed-size type.
+func (b *Buffer[T]) UnmarshalBinary(data []byte) error {
+	// decode before resetting, so that invalid data leaves the buffer untouched
+	buf, err := decodeBinary[T](data, defaultBinaryConfig(), nil)
+	if err != nil {
+		return err
+	}
+
+	b.Reset()
+	b.buf = buf
+	b.shared = false
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, encoding the
+// unread portion of the slice as described in the package-level MarshalBinary,
+// with the default options: big-endian byte order. To set the byte order with
+// WithByteOrder, use the package-level MarshalBinary instead.
+// T must be a fixed-size type.
+func (r *Reader[T]) MarshalBinary() ([]byte, error) {
+	if r.i >= int64(len(r.s)) {
+		return MarshalBinary[T](nil)
+	}
+
+	return MarshalBinary(r.s[r.i:])
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, resetting
+// the Reader to read from the T items decoded from data, as described in the
+// package-level UnmarshalBinary. T must be a fixed-size type.
+func (r *Reader[T]) UnmarshalBinary(data []byte) error {
+	items, err := UnmarshalBinary[T](data)
+	if err != nil {
+		return err
+	}
+
+	r.Reset(items)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package gbuf
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = new(Buffer[float64])
+	_ encoding.BinaryUnmarshaler = new(Buffer[float64])
+	_ encoding.BinaryMarshaler   = new(Reader[float64])
+	_ encoding.BinaryUnmarshaler = new(Reader[float64])
+)
+
+type point struct {
+	X, Y int32
+	Tag  uint8
+}
+
+func TestBuffer_MarshalBinary(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input []point
+		order binary.ByteOrder
+	}{
+		{
+			name:  "Empty",
+			order: binary.BigEndian,
+		},
+		{
+			name:  "BigEndian",
+			input: []point{{1, 2, 3}, {-4, 5, 6}},
+			order: binary.BigEndian,
+		},
+		{
+			name:  "LittleEndian",
+			input: []point{{1, 2, 3}, {-4, 5, 6}, {7, -8, 9}},
+			order: binary.LittleEndian,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			data, err := MarshalBinary(testcase.input, WithByteOrder(testcase.order))
+			require.NoError(t, err)
+
+			buf := NewBuffer([]point{{0, 0, 1}})
+			require.NoError(t, buf.UnmarshalBinary(data))
+			require.Equal(t, len(testcase.input), buf.Len())
+
+			if len(testcase.input) > 0 {
+				require.Equal(t, testcase.input, buf.Value())
+			}
+
+			reader := new(Reader[point])
+			require.NoError(t, reader.UnmarshalBinary(data))
+			require.Equal(t, len(testcase.input), reader.Len())
+
+			encoded, err := buf.MarshalBinary()
+			require.NoError(t, err)
+
+			// the buffer re-encodes the data in big-endian byte order
+			if testcase.order == binary.BigEndian {
+				require.Equal(t, data, encoded)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBinary_Errors(t *testing.T) {
+	valid, err := MarshalBinary([]uint16{1, 2, 3})
+	require.NoError(t, err)
+
+	for _, testcase := range []struct {
+		name     string
+		data     []byte
+		maxItems int
+		err      error
+	}{
+		{name: "Empty", data: nil, err: ErrBinaryInvalidHeader},
+		{name: "InvalidByteOrder", data: []byte{7, 0}, err: ErrBinaryInvalidHeader},
+		{name: "MissingLength", data: []byte{0}, err: ErrBinaryInvalidHeader},
+		{name: "ShortData", data: valid[:len(valid)-1], err: ErrBinaryInvalidLength},
+		{name: "TooManyItems", data: valid, maxItems: 2, err: ErrBinaryTooLarge},
+		{
+			name: "AllocationBomb",
+			data: binary.AppendUvarint([]byte{0}, 1<<62),
+			err:  ErrBinaryTooLarge,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := UnmarshalBinary[uint16](testcase.data, WithMaxItems(testcase.maxItems))
+			require.ErrorIs(t, err, testcase.err)
+		})
+	}
+
+	// 4 * (1<<62 + 1) overflows into a length of 4
+	overflow := append(binary.AppendUvarint([]byte{0}, 1<<62+1), 0, 0, 0, 1)
+	_, err = UnmarshalBinary[uint32](overflow, WithMaxItems(math.MaxInt))
+	require.ErrorIs(t, err, ErrBinaryInvalidLength)
+
+	_, err = MarshalBinary([]int{1})
+	require.ErrorIs(t, err, ErrBinaryUnsupportedType)
+}
+
+func TestBuffer_UnmarshalBinaryInvalid(t *testing.T) {
+	valid, err := MarshalBinary([]uint16{1, 2, 3})
+	require.NoError(t, err)
+
+	for _, testcase := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "InvalidByteOrder", data: []byte{7, 0}},
+		{name: "ShortData", data: valid[:len(valid)-1]},
+		{name: "TooManyItems", data: binary.AppendUvarint([]byte{0}, MaxBinaryItems+1)},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			buf := NewBuffer([]uint16{7, 8, 9})
+			_, err := buf.ReadItem()
+			require.NoError(t, err)
+
+			require.Error(t, buf.UnmarshalBinary(testcase.data))
+			require.Equal(t, []uint16{8, 9}, buf.Value())
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package gbuf
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockingPriorityQueue is a PriorityQueue safe for concurrent use, where
+// consumers can wait for items to be pushed, and producers can wait for
+// space, if the queue has a capacity bound.
+//
+// Closing the queue wakes all waiting consumers and producers. Items already
+// in a closed queue can still be popped; once it is empty, PopWait returns
+// ErrBlockingPriorityQueueClosed.
+//
+// The zero value is not usable; create one with NewBlockingPriorityQueue.
+type BlockingPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	h        lessHeap[T]
+	capacity int
+	closed   bool
+
+	// pushed and popped are closed and replaced when items are pushed or
+	// popped (respectively), or when the queue is closed, waking any waiters.
+	pushed chan struct{}
+	popped chan struct{}
+
+	waiters int // number of goroutines blocked in PushWait or PopWait
+}
+
+// NewBlockingPriorityQueue creates a BlockingPriorityQueue ordered by less,
+// which reports whether a must be popped before b. If capacity is positive,
+// the queue holds at most capacity items, and pushers block while it is full.
+func NewBlockingPriorityQueue[T any](less func(a, b T) bool, capacity int) *BlockingPriorityQueue[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	return &BlockingPriorityQueue[T]{
+		h: lessHeap[T]{
+			items: make([]T, 0, capacity),
+			less:  less,
+		},
+		capacity: capacity,
+		pushed:   make(chan struct{}),
+		popped:   make(chan struct{}),
+	}
+}
+
+// Len returns the number of items in the queue.
+func (q *BlockingPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.h.items)
+}
+
+// Cap returns the capacity bound of the queue, or zero if it is unbounded.
+func (q *BlockingPriorityQueue[T]) Cap() int { return q.capacity }
+
+// Push adds item to the queue, blocking while the queue is full. It returns
+// ErrBlockingPriorityQueueClosed if the queue is closed.
+func (q *BlockingPriorityQueue[T]) Push(item T) error {
+	return q.PushWait(context.Background(), item)
+}
+
+// PushWait adds item to the queue, blocking while the queue is full until ctx
+// is done. It returns ErrBlockingPriorityQueueClosed if the queue is closed, or
+// the context's error if ctx is done first.
+func (q *BlockingPriorityQueue[T]) PushWait(ctx context.Context, item T) error {
+	for {
+		q.mu.Lock()
+
+		if q.closed {
+			q.mu.Unlock()
+
+			return ErrBlockingPriorityQueueClosed
+		}
+
+		if q.capacity == 0 || len(q.h.items) < q.capacity {
+			Push[T](&q.h, item)
+			q.pushed = notify(q.pushed)
+			q.mu.Unlock()
+
+			return nil
+		}
+
+		if err := q.wait(ctx, q.popped); err != nil {
+			return err
+		}
+	}
+}
+
+// TryPop removes and returns the first item in the queue (according to less),
+// without blocking. It returns false if the queue is empty.
+func (q *BlockingPriorityQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.h.items) == 0 {
+		return *new(T), false
+	}
+
+	return q.pop(), true
+}
+
+// PopWait removes and returns the first item in the queue (according to less),
+// blocking while the queue is empty until ctx is done. It returns
+// ErrBlockingPriorityQueueClosed if the queue is closed and empty, or the
+// context's error if ctx is done first.
+func (q *BlockingPriorityQueue[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+
+		if len(q.h.items) > 0 {
+			item := q.pop()
+			q.mu.Unlock()
+
+			return item, nil
+		}
+
+		if q.closed {
+			q.mu.Unlock()
+
+			return *new(T), ErrBlockingPriorityQueueClosed
+		}
+
+		if err := q.wait(ctx, q.pushed); err != nil {
+			return *new(T), err
+		}
+	}
+}
+
+// wait releases q.mu, which must be held, and blocks until ch is closed or ctx
+// is done, counting the caller in q.waiters meanwhile.
+func (q *BlockingPriorityQueue[T]) wait(ctx context.Context, ch <-chan struct{}) error {
+	q.waiters++
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.waiters--
+		q.mu.Unlock()
+	}()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pop removes the first item from the heap, waking blocked pushers. q.mu must
+// be held.
+func (q *BlockingPriorityQueue[T]) pop() T {
+	item := Pop[T](&q.h)
+
+	if q.capacity > 0 {
+		q.popped = notify(q.popped)
+	}
+
+	return item
+}
+
+// Close closes the queue, waking all blocked pushers and poppers. Calling Close
+// more than once has no effect.
+func (q *BlockingPriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	q.pushed = notify(q.pushed)
+	q.popped = notify(q.popped)
+}
+
+// notify closes ch, waking any goroutines waiting on it, and returns a new
+// channel to wait on.
+func notify(ch chan struct{}) chan struct{} {
+	close(ch)
+
+	return make(chan struct{})
+}
@@ -0,0 +1,217 @@
+package gbuf
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitForWaiters waits until n goroutines are blocked on q.
+func waitForWaiters[T any](t *testing.T, q *BlockingPriorityQueue[T], n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return q.waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestBlockingPriorityQueue_Order(t *testing.T) {
+	q := NewBlockingPriorityQueue(intLess, 0)
+
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		require.NoError(t, q.Push(v))
+	}
+
+	require.Equal(t, 5, q.Len())
+	require.Zero(t, q.Cap())
+
+	for want := 1; want <= 5; want++ {
+		v, err := q.PopWait(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, want, v)
+	}
+
+	_, ok := q.TryPop()
+	require.False(t, ok)
+}
+
+func TestBlockingPriorityQueue_PopWait(t *testing.T) {
+	q := NewBlockingPriorityQueue(intLess, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := q.PopWait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	result := make(chan int)
+
+	go func() {
+		v, err := q.PopWait(context.Background())
+		if err != nil {
+			v = -1
+		}
+
+		result <- v
+	}()
+
+	waitForWaiters(t, q, 1)
+	require.NoError(t, q.Push(42))
+	require.Equal(t, 42, <-result)
+}
+
+func TestBlockingPriorityQueue_Close(t *testing.T) {
+	q := NewBlockingPriorityQueue(intLess, 0)
+	require.NoError(t, q.Push(1))
+
+	errs := make(chan error, 2)
+
+	q.Close()
+	q.Close()
+
+	// remaining items are still returned
+	v, err := q.PopWait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	go func() {
+		_, err := q.PopWait(context.Background())
+		errs <- err
+	}()
+
+	require.ErrorIs(t, <-errs, ErrBlockingPriorityQueueClosed)
+	require.ErrorIs(t, q.Push(2), ErrBlockingPriorityQueueClosed)
+
+	// waiting poppers are woken on Close
+	q = NewBlockingPriorityQueue(intLess, 0)
+
+	for range 2 {
+		go func() {
+			_, err := q.PopWait(context.Background())
+			errs <- err
+		}()
+	}
+
+	waitForWaiters(t, q, 2)
+	q.Close()
+
+	require.ErrorIs(t, <-errs, ErrBlockingPriorityQueueClosed)
+	require.ErrorIs(t, <-errs, ErrBlockingPriorityQueueClosed)
+}
+
+func TestBlockingPriorityQueue_Capacity(t *testing.T) {
+	q := NewBlockingPriorityQueue(intLess, 2)
+	require.Equal(t, 2, q.Cap())
+
+	require.NoError(t, q.Push(3))
+	require.NoError(t, q.Push(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, q.PushWait(ctx, 2), context.DeadlineExceeded)
+
+	pushed := make(chan error)
+
+	go func() { pushed <- q.Push(2) }()
+
+	waitForWaiters(t, q, 1)
+
+	select {
+	case err := <-pushed:
+		t.Fatalf("push on a full queue returned early: %v", err)
+	default:
+	}
+
+	v, ok := q.TryPop()
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.NoError(t, <-pushed)
+
+	// blocked pushers are woken on Close
+	go func() { pushed <- q.Push(4) }()
+
+	waitForWaiters(t, q, 1)
+	q.Close()
+
+	require.ErrorIs(t, <-pushed, ErrBlockingPriorityQueueClosed)
+}
+
+func TestBlockingPriorityQueue_Concurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 250
+	)
+
+	q := NewBlockingPriorityQueue(intLess, 16)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		received []int
+	)
+
+	for range consumers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				v, err := q.PopWait(context.Background())
+				if err != nil {
+					return
+				}
+
+				mu.Lock()
+				received = append(received, v)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var pwg sync.WaitGroup
+
+	pushErrs := make([]error, producers)
+
+	for p := range producers {
+		pwg.Add(1)
+
+		go func() {
+			defer pwg.Done()
+
+			for i := range perWorker {
+				if err := q.Push(p*perWorker + i); err != nil {
+					pushErrs[p] = err
+
+					return
+				}
+			}
+		}()
+	}
+
+	pwg.Wait()
+
+	for _, err := range pushErrs {
+		require.NoError(t, err)
+	}
+
+	q.Close()
+	wg.Wait()
+
+	slices.Sort(received)
+
+	require.Len(t, received, producers*perWorker)
+
+	for i := range received {
+		require.Equal(t, i, received[i])
+	}
+}
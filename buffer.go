@@ -5,6 +5,7 @@ package gbuf
 import (
 	"errors"
 	"io"
+	"iter"
 	"sync/atomic"
 
 	"github.com/zalgonoise/gio"
@@ -18,6 +19,12 @@ const smallBufferSize = 64
 type Buffer[T any] struct {
 	buf       []T         // contents are the T items buf[off : len(buf)]
 	off       int         // read at &buf[off], write at &buf[len(buf)]
+	base      int         // stream position of buf[0], used to resolve marks
+	keep      int         // stream position of the earliest outstanding mark
+	marked    bool        // whether there are outstanding marks to retain on compaction
+	shared    bool        // buf is shared with a Clone or Snapshot; copy before writing.
+	idleCap   int         // maximum capacity retained on Reset; zero means unbounded
+	chunk     int         // minimum slice size passed to Read by ReadFrom; zero means MinRead
 	isReading atomic.Bool // last operation type, so that Unread* can work correctly.
 }
 
@@ -61,17 +68,214 @@ func (b *Buffer[T]) Truncate(n int) {
 
 // Reset resets the buffer to be empty,
 // but it retains the underlying storage for use by future writes.
-// Reset is the same as Truncate(0).
+// Reset is the same as Truncate(0). Any outstanding marks are invalidated.
+//
+// If an automatic shrink policy is set with AutoShrink, and the buffer's capacity
+// exceeds it, the underlying storage is replaced by a smaller one.
 func (b *Buffer[T]) Reset() {
+	b.base += len(b.buf)
+	b.marked = false
 	b.buf = b.buf[:0]
 	b.off = 0
 	b.isReading.Store(false)
+
+	if b.idleCap > 0 && cap(b.buf) > b.idleCap {
+		b.buf = make([]T, 0, b.idleCap)
+		b.shared = false
+	}
+}
+
+// AutoShrink sets an automatic shrink policy for the buffer: whenever it is Reset
+// (including when it is drained by a read operation), if its capacity exceeds
+// maxCap, the underlying storage is released and replaced with one of capacity
+// maxCap. This prevents long-lived buffers from holding on to their peak capacity
+// after a burst of writes. A maxCap of zero or less disables the policy.
+func (b *Buffer[T]) AutoShrink(maxCap int) {
+	if maxCap < 0 {
+		maxCap = 0
+	}
+
+	b.idleCap = maxCap
+}
+
+// Compact slides the unread portion of the buffer to the front of its underlying
+// storage, dropping the T items that were already read; except for those still
+// referenced by an outstanding mark. Compact does not release memory; use Shrink
+// for that purpose.
+func (b *Buffer[T]) Compact() {
+	b.isReading.Store(false)
+
+	// If the backing array is shared, copying it already compacts the buffer.
+	if b.shared {
+		b.unshare(0)
+		return
+	}
+
+	k := b.retained()
+	if k == 0 {
+		return
+	}
+
+	m := copy(b.buf, b.buf[k:])
+	// clear the stale T items so they can be garbage collected.
+	clear(b.buf[m:])
+
+	b.buf = b.buf[:m]
+	b.base += k
+	b.off -= k
+}
+
+// Shrink reallocates the buffer's underlying storage to a smaller one if its
+// capacity exceeds maxCap, dropping the T items that were already read in the
+// process (like Compact). The new capacity is maxCap, or the length of the data
+// that must be kept, whichever is larger.
+// If maxCap is negative, Shrink will panic.
+func (b *Buffer[T]) Shrink(maxCap int) {
+	if maxCap < 0 {
+		panic(ErrBufferNegativeShrink)
+	}
+
+	k := b.retained()
+	m := len(b.buf) - k
+	c := max(m, maxCap)
+
+	if cap(b.buf) <= c {
+		return
+	}
+
+	buf := make([]T, m, c)
+	copy(buf, b.buf[k:])
+
+	b.buf = buf
+	b.base += k
+	b.off -= k
+	b.shared = false
+	b.isReading.Store(false)
+}
+
+// resetEmpty resets an empty buffer to recover space, unless there are
+// outstanding marks that still reference its read data.
+func (b *Buffer[T]) resetEmpty() {
+	if b.marked {
+		return
+	}
+
+	b.Reset()
+}
+
+// Mark is a read checkpoint in a Buffer, as returned by Buffer.Mark.
+type Mark struct {
+	pos int
+}
+
+// Mark returns a checkpoint of the buffer's current read offset, which can be
+// restored with Rewind. While there are outstanding marks, compacting the buffer
+// (when it grows, or when it is drained) retains the T items from the earliest
+// mark onwards, so that they can be read again. Call Unmark to release them.
+func (b *Buffer[T]) Mark() Mark {
+	pos := b.base + b.off
+
+	if !b.marked || pos < b.keep {
+		b.keep = pos
+	}
+
+	b.marked = true
+
+	return Mark{pos: pos}
+}
+
+// Rewind restores the buffer's read offset to the checkpoint m, returned by a
+// previous call to Mark. It returns ErrBufferInvalidMark if the T items at the
+// checkpoint are no longer held by the buffer, which is the case when the buffer
+// was Reset, or compacted after a call to Unmark.
+func (b *Buffer[T]) Rewind(m Mark) error {
+	off := m.pos - b.base
+	if off < 0 || off > len(b.buf) {
+		return ErrBufferInvalidMark
+	}
+
+	b.off = off
+	b.isReading.Store(false)
+
+	return nil
+}
+
+// Unmark releases all outstanding marks, allowing the buffer to discard read T
+// items when compacting. Marks taken before the call may still be used with
+// Rewind until the buffer is compacted.
+func (b *Buffer[T]) Unmark() {
+	b.marked = false
+}
+
+// retained returns the index of the first T item that must be kept when
+// compacting the buffer: the read offset, or the earliest outstanding mark.
+func (b *Buffer[T]) retained() int {
+	if b.marked {
+		if k := b.keep - b.base; k >= 0 && k < b.off {
+			return k
+		}
+	}
+
+	return b.off
+}
+
+// Clone returns a new Buffer holding the unread portion of b. The clone shares
+// b's backing array until either of them is written to, at which point the
+// writing side copies its unread data into its own storage (copy-on-write).
+// Reads on either Buffer do not affect the other.
+func (b *Buffer[T]) Clone() *Buffer[T] {
+	b.shared = true
+
+	return &Buffer[T]{
+		buf:    b.buf[b.off:],
+		shared: true,
+	}
+}
+
+// Snapshot returns a Reader over the unread portion of b, as it is at the time
+// of the call. The Reader shares b's backing array, and b copies its data into
+// new storage on the next write, so the snapshot remains frozen while b keeps
+// being written to. The Reader may be drained concurrently with writes to b.
+func (b *Buffer[T]) Snapshot() *Reader[T] {
+	b.shared = true
+
+	return NewReader(b.buf[b.off:])
+}
+
+// unshare detaches the buffer from a backing array shared through Clone or
+// Snapshot, copying the unread portion of the buffer into newly allocated
+// storage, with room for at least n more T items.
+// If the buffer can't grow it will panic with ErrBufferTooLarge.
+func (b *Buffer[T]) unshare(n int) {
+	k := b.retained()
+	m := len(b.buf) - k
+
+	if n > maxInt-m {
+		panic(ErrBufferTooLarge)
+	}
+
+	c := cap(b.buf) - k
+	if c < m+n {
+		c = m + n
+	}
+
+	buf := make([]T, m, c)
+	copy(buf, b.buf[k:])
+
+	b.buf = buf
+	b.base += k
+	b.off -= k
+	b.shared = false
 }
 
 // tryGrowByReslice is a inlineable version of grow for the fast-case where the
 // internal buffer only needs to be resliced.
 // It returns the index where T items should be written and whether it succeeded.
 func (b *Buffer[T]) tryGrowByReslice(n int) (int, bool) {
+	if b.shared {
+		return 0, false
+	}
+
 	if l := len(b.buf); n <= cap(b.buf)-l {
 		b.buf = b.buf[:l+n]
 		return l, true
@@ -84,10 +288,14 @@ func (b *Buffer[T]) tryGrowByReslice(n int) (int, bool) {
 // It returns the index where T items should be written.
 // If the buffer can't grow it will panic with ErrBufferTooLarge.
 func (b *Buffer[T]) grow(n int) int {
-	m := b.Len()
+	// If the backing array is shared, copy it before writing.
+	if b.shared {
+		b.unshare(n)
+	}
+
 	// If buffer is empty, reset to recover space.
-	if m == 0 && b.off != 0 {
-		b.Reset()
+	if b.Len() == 0 && b.off != 0 {
+		b.resetEmpty()
 	}
 	// Try to grow by means of a reslice.
 	if i, ok := b.tryGrowByReslice(n); ok {
@@ -100,6 +308,9 @@ func (b *Buffer[T]) grow(n int) int {
 	}
 
 	c := cap(b.buf)
+	// Keep the unread T items, and any read T items still referenced by a mark.
+	k := b.retained()
+	m := len(b.buf) - k
 
 	switch {
 	case n <= c/2-m:
@@ -107,16 +318,17 @@ func (b *Buffer[T]) grow(n int) int {
 		// slice. We only need m+n <= c to slide, but
 		// we instead let capacity get twice as large so we
 		// don't spend all our time copying.
-		copy(b.buf, b.buf[b.off:])
+		copy(b.buf, b.buf[k:])
 	case c > maxInt-c-n:
 		panic(ErrBufferTooLarge)
 	default:
-		// Add b.off to account for b.buf[:b.off] being sliced off the front.
-		b.buf = growSlice(b.buf[b.off:], b.off+n)
+		// Add k to account for b.buf[:k] being sliced off the front.
+		b.buf = growSlice(b.buf[k:], k+n)
 	}
 
 	// Restore b.off and len(b.buf).
-	b.off = 0
+	b.base += k
+	b.off -= k
 	b.buf = b.buf[:m+n]
 
 	return m
@@ -153,19 +365,66 @@ func (b *Buffer[T]) Write(p []T) (n int, err error) {
 // MinRead is the minimum slice size passed to a Read call by
 // Buffer.ReadFrom. As long as the Buffer has at least MinRead T items beyond
 // what is required to hold the contents of r, ReadFrom will not grow the
-// underlying buffer.
+// underlying buffer. It can be changed for a single Buffer with SetChunkSize.
 const MinRead = 512
 
+// SetChunkSize sets the minimum slice size passed to a Read call by ReadFrom
+// and ReadFromN, for this buffer. A chunk size of zero or less restores the
+// default, MinRead.
+func (b *Buffer[T]) SetChunkSize(n int) {
+	if n < 0 {
+		n = 0
+	}
+
+	b.chunk = n
+}
+
+// chunkSize returns the minimum slice size passed to a Read call by ReadFrom.
+func (b *Buffer[T]) chunkSize() int {
+	if b.chunk > 0 {
+		return b.chunk
+	}
+
+	return MinRead
+}
+
 // ReadFrom reads data from r until EOF and appends it to the buffer, growing
 // the buffer as needed. The return value n is the number of T items read. Any
 // error except io.EOF encountered during the read is also returned. If the
 // buffer becomes too large, ReadFrom will panic with ErrBufferTooLarge.
+//
+// If r exposes the number of T items it holds, through a Len() int or a
+// Size() int64 method (like a Reader does), it is used as a size hint, as
+// with ReadFromN.
 func (b *Buffer[T]) ReadFrom(r gio.Reader[T]) (n int64, err error) {
+	return b.ReadFromN(r, sizeHint(r))
+}
+
+// ReadFromN is like ReadFrom, but it takes a hint of the number of T items
+// that r will return. The buffer is grown once to hold the hinted size before
+// reading, and is only grown further if r returns more T items than hinted.
+// A hint of zero or less is ignored.
+func (b *Buffer[T]) ReadFromN(r gio.Reader[T], hint int) (n int64, err error) {
 	b.isReading.Store(false)
 
+	if hint > 0 && hint < maxInt {
+		// Grow one extra T item, so that r can return io.EOF without
+		// the buffer growing again.
+		b.Grow(hint + 1)
+	} else {
+		hint = 0
+	}
+
 	for {
-		i := b.grow(MinRead)
-		b.buf = b.buf[:i]
+		i := len(b.buf)
+
+		// Once the hinted size is read, grow the buffer as usual.
+		if hint == 0 || i == cap(b.buf) {
+			hint = 0
+			i = b.grow(b.chunkSize())
+			b.buf = b.buf[:i]
+		}
+
 		m, e := r.Read(b.buf[i:cap(b.buf)])
 
 		if m < 0 {
@@ -185,6 +444,21 @@ func (b *Buffer[T]) ReadFrom(r gio.Reader[T]) (n int64, err error) {
 	}
 }
 
+// sizeHint returns the number of T items held by r, if it exposes it through a
+// Len() int or a Size() int64 method. Otherwise, it returns zero.
+func sizeHint[T any](r gio.Reader[T]) int {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return v.Len()
+	case interface{ Size() int64 }:
+		if size := v.Size(); size > 0 && size <= int64(maxInt) {
+			return int(size)
+		}
+	}
+
+	return 0
+}
+
 // growSlice grows b by n, preserving the original content of b.
 // If the allocation fails, it panics with ErrBufferTooLarge.
 func growSlice[T any](b []T, n int) []T {
@@ -241,7 +515,7 @@ func (b *Buffer[T]) WriteTo(w gio.Writer[T]) (n int64, err error) {
 	}
 
 	// Buffer is now empty; reset.
-	b.Reset()
+	b.resetEmpty()
 
 	return n, nil
 }
@@ -272,7 +546,7 @@ func (b *Buffer[T]) Read(p []T) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if len(p) == 0 {
 			return 0, nil
@@ -318,7 +592,7 @@ func (b *Buffer[T]) Next(n int) []T {
 func (b *Buffer[T]) ReadItem() (T, error) {
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		var zero T
 
@@ -350,6 +624,24 @@ func (b *Buffer[T]) UnreadItem() error {
 	return nil
 }
 
+// UnreadItems unreads the last n T items read from the buffer, so that they are
+// returned again by the next read operation. Unlike UnreadItem, it may be called
+// after any operation, as long as the buffer still holds those T items; that is,
+// as long as it was not Reset or compacted since they were read. To
+// keep read T items across compactions, use Mark and Rewind.
+// If n is negative or the buffer does not hold n read T items, UnreadItems
+// returns an error.
+func (b *Buffer[T]) UnreadItems(n int) error {
+	if n < 0 || n > b.off {
+		return ErrBufferUnreadItems
+	}
+
+	b.off -= n
+	b.isReading.Store(false)
+
+	return nil
+}
+
 // ReadItems reads until the first occurrence of delim in the input,
 // returning a slice containing the data up to and including the delimiter.
 // If ReadT items encounters an error before finding a delimiter,
@@ -406,3 +698,49 @@ func NewBuffer[T any](buf []T) *Buffer[T] {
 		buf: buf,
 	}
 }
+
+// All returns an iterator over the index-value pairs of the unread portion of
+// the buffer, in order, without consuming them. The behavior of All is undefined
+// if the buffer is modified during iteration.
+func (b *Buffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, item := range b.buf[b.off:] {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index-value pairs of the unread portion
+// of the buffer, in reverse order, without consuming them. The behavior of
+// Backward is undefined if the buffer is modified during iteration.
+func (b *Buffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		items := b.buf[b.off:]
+
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(i, items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that reads the items in the buffer, one at a time,
+// until it is drained. Items written to the buffer during iteration are also
+// read. Stopping the iteration early leaves the remaining items unread.
+func (b *Buffer[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, err := b.ReadItem()
+			if err != nil {
+				return
+			}
+
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gbuf
+
+import "io"
+
+// ComparableBuffer is a Buffer for types that allow comparisons, unlocking search
+// features over multi-item separators, such as CRLF sequences or frame markers.
+type ComparableBuffer[T comparable] struct {
+	*Buffer[T]
+}
+
+// Index returns the index of the first instance of sep in the unread portion of the
+// buffer, or -1 if sep is not present.
+func (b *ComparableBuffer[T]) Index(sep []T) int {
+	return Index(b.buf[b.off:], sep)
+}
+
+// Contains reports whether sep is within the unread portion of the buffer.
+func (b *ComparableBuffer[T]) Contains(sep []T) bool {
+	return b.Index(sep) >= 0
+}
+
+// ReadUntil reads until the first occurrence of sep in the input,
+// returning a slice containing the data up to and including the separator.
+// If ReadUntil encounters an error before finding a separator,
+// it returns the data read before the error and the error itself (often io.EOF).
+// ReadUntil returns err != nil if and only if the returned data does not end in sep.
+// An empty sep is rejected with ErrBufferInvalidSeparator, without reading.
+func (b *ComparableBuffer[T]) ReadUntil(sep []T) (line []T, err error) {
+	if len(sep) == 0 {
+		return nil, ErrBufferInvalidSeparator
+	}
+
+	end := len(b.buf)
+
+	if i := b.Index(sep); i >= 0 {
+		end = b.off + i + len(sep)
+	} else {
+		err = io.EOF
+	}
+
+	// return a copy of slice. The buffer's backing array may
+	// be overwritten by later calls.
+	line = append(line, b.buf[b.off:end]...)
+	b.off = end
+	b.isReading.Store(true)
+
+	return line, err
+}
+
+// NewComparableBuffer creates and initializes a new Buffer using buf as its
+// initial contents, as a ComparableBuffer. The new Buffer takes ownership of buf,
+// and the caller should not use buf after this call.
+func NewComparableBuffer[T comparable](buf []T) *ComparableBuffer[T] {
+	return &ComparableBuffer[T]{
+		Buffer: NewBuffer(buf),
+	}
+}
+
+// AsComparableBuffer converts a Buffer[T] into a ComparableBuffer[T]
+func AsComparableBuffer[T comparable](buf *Buffer[T]) *ComparableBuffer[T] {
+	return &ComparableBuffer[T]{
+		Buffer: buf,
+	}
+}
+
+// ComparablePeekBuffer is a PeekBuffer for types that allow comparisons, exposing the same
+// search features as a ComparableBuffer.
+type ComparablePeekBuffer[T comparable] struct {
+	*PeekBuffer[T]
+}
+
+// Index returns the index of the first instance of sep in the unread portion of the
+// buffer, or -1 if sep is not present.
+func (b *ComparablePeekBuffer[T]) Index(sep []T) int {
+	return Index(b.buf[b.off:], sep)
+}
+
+// Contains reports whether sep is within the unread portion of the buffer.
+func (b *ComparablePeekBuffer[T]) Contains(sep []T) bool {
+	return b.Index(sep) >= 0
+}
+
+// ReadUntil is just like ComparableBuffer.ReadUntil, reading until the first occurrence
+// of sep in the input, returning a slice containing the data up to and including the
+// separator.
+func (b *ComparablePeekBuffer[T]) ReadUntil(sep []T) (line []T, err error) {
+	return AsComparableBuffer(b.Buffer).ReadUntil(sep)
+}
+
+// PeekUntil is just like ReadUntil, however it does not advance the buffer's offset
+// after the items are read. An empty sep is rejected with
+// ErrPeekBufferInvalidSeparator.
+func (b *ComparablePeekBuffer[T]) PeekUntil(sep []T) (line []T, err error) {
+	if len(sep) == 0 {
+		return nil, ErrPeekBufferInvalidSeparator
+	}
+
+	end := len(b.buf)
+
+	if i := b.Index(sep); i >= 0 {
+		end = b.off + i + len(sep)
+	} else {
+		err = io.EOF
+	}
+
+	return append(line, b.buf[b.off:end]...), err
+}
+
+// AsComparablePeekBuffer converts a PeekBuffer[T] into a ComparablePeekBuffer[T]
+func AsComparablePeekBuffer[T comparable](buf *PeekBuffer[T]) *ComparablePeekBuffer[T] {
+	return &ComparablePeekBuffer[T]{
+		PeekBuffer: buf,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/zalgonoise/gbuf"
@@ -304,6 +305,118 @@ func TestReadFrom(t *testing.T) {
 	}
 }
 
+// chunkReader records the length of the slices passed to Read.
+type chunkReader struct {
+	r     *gbuf.Reader[byte]
+	sizes []int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	r.sizes = append(r.sizes, len(p))
+
+	return r.r.Read(p)
+}
+
+func TestReadFromSizeHint(t *testing.T) {
+	var buf gbuf.Buffer[byte]
+
+	// gbuf.Reader exposes its Len, so the buffer is allocated once
+	n, err := buf.ReadFrom(gbuf.NewReader(testBytes))
+	if err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+
+	if n != N {
+		t.Errorf("ReadFrom: n == %d, want %d", n, N)
+	}
+
+	check(t, "TestReadFromSizeHint", &buf, testString)
+
+	// reading from the hinted source must allocate just like a single Grow call
+	growAllocs := testing.AllocsPerRun(10, func() {
+		buf.Truncate(0)
+		buf.Shrink(0)
+		buf.Grow(N)
+	})
+
+	r := gbuf.NewReader(testBytes)
+	allocs := testing.AllocsPerRun(10, func() {
+		buf.Truncate(0)
+		buf.Shrink(0)
+		r.Reset(testBytes)
+
+		_, _ = buf.ReadFrom(r)
+	})
+
+	if allocs != growAllocs {
+		t.Errorf("ReadFrom: got %v allocs, want %v", allocs, growAllocs)
+	}
+}
+
+func TestReadFromN(t *testing.T) {
+	for _, hint := range []int{0, 1, 100, N, 2 * N} {
+		var buf gbuf.Buffer[byte]
+
+		n, err := buf.ReadFromN(&chunkReader{r: gbuf.NewReader(testBytes)}, hint)
+		if err != nil {
+			t.Fatalf("ReadFromN(%d): %v", hint, err)
+		}
+
+		if n != N {
+			t.Errorf("ReadFromN(%d): n == %d, want %d", hint, n, N)
+		}
+
+		check(t, fmt.Sprintf("TestReadFromN (%d)", hint), &buf, testString)
+	}
+}
+
+func TestSetChunkSize(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		init  []byte
+		chunk int
+		wants []int
+	}{
+		{
+			// 4 free T items fit a chunk of 4, so the first Read gets them as-is
+			name:  "Small",
+			init:  make([]byte, 60, 64),
+			chunk: 4,
+			wants: []int{4, 64, 128, 256, 512, 1024, 2048, 1036},
+		},
+		{
+			name:  "SmallDefault",
+			init:  make([]byte, 60, 64),
+			wants: []int{516, 576, 1152, 2560, 1804},
+		},
+		{
+			name:  "Large",
+			chunk: 1024,
+			wants: []int{1024, 1024, 2048, 1096},
+		},
+		{
+			name:  "LargeDefault",
+			wants: []int{512, 512, 1024, 2048, 1096},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			buf := gbuf.NewBuffer(testcase.init)
+			buf.SetChunkSize(testcase.chunk)
+			r := &chunkReader{r: gbuf.NewReader(testBytes[:3000])}
+
+			if _, err := buf.ReadFromN(r, 0); err != nil {
+				t.Fatalf("ReadFromN: %v", err)
+			}
+
+			check(t, "TestSetChunkSize", buf, string(testcase.init)+testString[:3000])
+
+			if !slices.Equal(r.sizes, testcase.wants) {
+				t.Errorf("Read sizes == %v, want %v", r.sizes, testcase.wants)
+			}
+		})
+	}
+}
+
 type panicReader struct{ panic bool }
 
 func (r panicReader) Read(_ []byte) (int, error) {
@@ -573,6 +686,178 @@ func TestUnreadByte(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	buf := gbuf.NewBuffer(make([]byte, 0, 64))
+	_, _ = buf.Write([]byte("hello world"))
+	_, _ = buf.Read(make([]byte, 6))
+
+	clone := buf.Clone()
+	check(t, "TestClone (1)", clone, "world")
+
+	// writes on either side must not be visible to the other
+	_, _ = buf.Write([]byte("!!"))
+	_ = clone.WriteItem('?')
+
+	check(t, "TestClone (2)", buf, "world!!")
+	check(t, "TestClone (3)", clone, "world?")
+
+	buf.Truncate(2)
+	_, _ = buf.Write([]byte("ND"))
+
+	check(t, "TestClone (4)", buf, "woND")
+	check(t, "TestClone (5)", clone, "world?")
+}
+
+func TestSnapshot(t *testing.T) {
+	var buf gbuf.Buffer[byte]
+
+	_, _ = buf.Write([]byte("frozen"))
+
+	snapshot := buf.Snapshot()
+
+	buf.Truncate(3)
+	_, _ = buf.Write([]byte("zen data"))
+
+	check(t, "TestSnapshot (1)", &buf, "frozen data")
+
+	got, err := io.ReadAll(snapshot)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	if string(got) != "frozen" {
+		t.Errorf("snapshot = %q; want %q", got, "frozen")
+	}
+}
+
+func TestUnreadItems(t *testing.T) {
+	buf := gbuf.NewBuffer(append(make([]byte, 0, 64), "abcdef"...))
+	_, _ = buf.Read(make([]byte, 4))
+
+	if err := buf.UnreadItems(5); !errors.Is(err, gbuf.ErrBufferUnreadItems) {
+		t.Fatalf("UnreadItems(5) = %v; want %v", err, gbuf.ErrBufferUnreadItems)
+	}
+
+	if err := buf.UnreadItems(-1); !errors.Is(err, gbuf.ErrBufferUnreadItems) {
+		t.Fatalf("UnreadItems(-1) = %v; want %v", err, gbuf.ErrBufferUnreadItems)
+	}
+
+	// writes do not prevent unreading, as long as the buffer is not compacted
+	_, _ = buf.Write([]byte("g"))
+
+	if err := buf.UnreadItems(3); err != nil {
+		t.Fatalf("UnreadItems(3): %v", err)
+	}
+
+	check(t, "TestUnreadItems", buf, "bcdefg")
+}
+
+func TestMarkRewind(t *testing.T) {
+	var buf gbuf.Buffer[byte]
+
+	_, _ = buf.Write([]byte("header:"))
+	_, _ = buf.ReadItems(func(b byte) bool { return b == ':' })
+
+	mark := buf.Mark()
+
+	// drain the buffer and force several compactions
+	for i := 0; i < 8; i++ {
+		_, _ = buf.Write(testBytes[:512])
+		_, _ = buf.Read(make([]byte, 512))
+	}
+
+	if _, err := buf.ReadItem(); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadItem = %v; want %v", err, io.EOF)
+	}
+
+	if err := buf.Rewind(mark); err != nil {
+		t.Fatalf("Rewind: %v", err)
+	}
+
+	got := buf.Next(512 * 8)
+	want := bytes.Repeat(testBytes[:512], 8)
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("Rewind: read %d items not matching written data", len(got))
+	}
+
+	// once released, compaction discards the marked items
+	buf.Unmark()
+	_, _ = buf.Write(testBytes)
+
+	if err := buf.Rewind(mark); !errors.Is(err, gbuf.ErrBufferInvalidMark) {
+		t.Errorf("Rewind after Unmark = %v; want %v", err, gbuf.ErrBufferInvalidMark)
+	}
+
+	mark = buf.Mark()
+	buf.Reset()
+
+	if err := buf.Rewind(mark); !errors.Is(err, gbuf.ErrBufferInvalidMark) {
+		t.Errorf("Rewind after Reset = %v; want %v", err, gbuf.ErrBufferInvalidMark)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	buf := gbuf.NewBuffer(make([]byte, 0, 64))
+	_, _ = buf.Write([]byte("consumed:unread"))
+	_, _ = buf.ReadItems(func(b byte) bool { return b == ':' })
+
+	buf.Compact()
+	check(t, "TestCompact (1)", buf, "unread")
+
+	if err := buf.UnreadItems(1); err == nil {
+		t.Error("UnreadItems after Compact: got no error")
+	}
+
+	if buf.Cap() != 64 {
+		t.Errorf("Compact: buf.Cap() == %d, want 64", buf.Cap())
+	}
+}
+
+func TestShrink(t *testing.T) {
+	var buf gbuf.Buffer[byte]
+
+	_, _ = buf.Write(testBytes)
+	_, _ = buf.Read(make([]byte, len(testBytes)-10))
+
+	buf.Shrink(4)
+	check(t, "TestShrink (1)", &buf, testString[len(testString)-10:])
+
+	if buf.Cap() != 10 {
+		t.Errorf("Shrink(4): buf.Cap() == %d, want 10", buf.Cap())
+	}
+
+	buf.Shrink(100)
+
+	if buf.Cap() != 10 {
+		t.Errorf("Shrink(100): buf.Cap() == %d, want 10", buf.Cap())
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, gbuf.ErrBufferNegativeShrink) {
+			t.Errorf("Shrink(-1): unexpected panic value: %#v", err)
+		}
+	}()
+
+	buf.Shrink(-1)
+}
+
+func TestAutoShrink(t *testing.T) {
+	var buf gbuf.Buffer[byte]
+
+	buf.AutoShrink(128)
+
+	_, _ = buf.Write(testBytes)
+	empty(t, "TestAutoShrink (1)", &buf, testString, make([]byte, 1024))
+
+	// draining the buffer resets it
+	_, _ = buf.ReadItem()
+
+	if buf.Cap() != 128 {
+		t.Errorf("AutoShrink: buf.Cap() == %d, want 128", buf.Cap())
+	}
+}
+
 // Tests that we occasionally compact. Issue 5154.
 func TestBufferGrowth(t *testing.T) {
 	var (
@@ -669,3 +954,56 @@ func BenchmarkBufferWriteBlock(b *testing.B) {
 		})
 	}
 }
+
+func TestBufferIterators(t *testing.T) {
+	buf := gbuf.NewBuffer([]byte("xabcd"))
+	_, _ = buf.ReadItem()
+
+	var forward, backward []byte
+
+	for i, c := range buf.All() {
+		if want := "abcd"[i]; c != want {
+			t.Errorf("All()[%d] = %q; want %q", i, c, want)
+		}
+
+		forward = append(forward, c)
+	}
+
+	for _, c := range buf.Backward() {
+		backward = append(backward, c)
+	}
+
+	if string(forward) != "abcd" || string(backward) != "dcba" {
+		t.Errorf("All() = %q, Backward() = %q; want %q, %q", forward, backward, "abcd", "dcba")
+	}
+
+	check(t, "TestBufferIterators (1)", buf, "abcd")
+
+	var drained []byte
+
+	for c := range buf.Drain() {
+		drained = append(drained, c)
+
+		if c == 'b' {
+			break
+		}
+	}
+
+	if string(drained) != "ab" {
+		t.Errorf("Drain() = %q; want %q", drained, "ab")
+	}
+
+	check(t, "TestBufferIterators (2)", buf, "cd")
+
+	drained = drained[:0]
+
+	for c := range buf.Drain() {
+		drained = append(drained, c)
+	}
+
+	if string(drained) != "cd" {
+		t.Errorf("Drain() = %q; want %q", drained, "cd")
+	}
+
+	check(t, "TestBufferIterators (3)", buf, "")
+}
@@ -0,0 +1,62 @@
+package gbuf
+
+import "time"
+
+// Clock provides the current time, timers and tickers to the time-based types in
+// this module, such as a DelayQueue, an LRU with a time-to-live, or a gbufio
+// Writer with a flush interval. It allows replacing the system clock, e.g. in
+// tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a Timer that delivers the current time on its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a Ticker that delivers the current time on its channel
+	// every duration d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer delivers a single event at a set time, like a time.Timer.
+type Timer interface {
+	// Chan returns the channel on which the event is delivered.
+	Chan() <-chan time.Time
+	// Stop prevents the Timer from firing, reporting whether it was stopped
+	// before firing.
+	Stop() bool
+}
+
+// Ticker delivers ticks at intervals, like a time.Ticker.
+type Ticker interface {
+	// Chan returns the channel on which the ticks are delivered.
+	Chan() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// SystemClock returns a Clock backed by the time package.
+func SystemClock() Clock { return systemClock{} }
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) Chan() <-chan time.Time { return t.C }
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) Chan() <-chan time.Time { return t.C }
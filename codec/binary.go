@@ -0,0 +1,76 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/zalgonoise/gbuf"
+)
+
+type binaryCodec[T any] struct {
+	order binary.ByteOrder
+}
+
+// Binary returns a Codec for fixed-size T items, as accepted by encoding/binary,
+// encoded back-to-back in the input byte order. If order is nil, big-endian is used.
+//
+// Decoding and encoding T items that are not fixed-size returns
+// gbuf.ErrBinaryUnsupportedType.
+func Binary[T any](order binary.ByteOrder) Codec[T] {
+	if order == nil {
+		order = binary.BigEndian
+	}
+
+	return binaryCodec[T]{order: order}
+}
+
+func (c binaryCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return &binaryDecoder[T]{
+		r:     bufio.NewReader(r),
+		order: c.order,
+		fixed: binary.Size(*new(T)) >= 0,
+	}
+}
+
+func (c binaryCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return &binaryEncoder[T]{
+		w:     bufio.NewWriter(w),
+		order: c.order,
+		fixed: binary.Size(*new(T)) >= 0,
+	}
+}
+
+type binaryDecoder[T any] struct {
+	r     *bufio.Reader
+	order binary.ByteOrder
+	fixed bool
+}
+
+func (d *binaryDecoder[T]) Decode() (item T, err error) {
+	if !d.fixed {
+		return item, gbuf.ErrBinaryUnsupportedType
+	}
+
+	err = binary.Read(d.r, d.order, &item)
+
+	return item, err
+}
+
+type binaryEncoder[T any] struct {
+	w     *bufio.Writer
+	order binary.ByteOrder
+	fixed bool
+}
+
+func (e *binaryEncoder[T]) Encode(item T) error {
+	if !e.fixed {
+		return gbuf.ErrBinaryUnsupportedType
+	}
+
+	return binary.Write(e.w, e.order, item)
+}
+
+func (e *binaryEncoder[T]) Flush() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,36 @@
+// Package codec adapts byte streams into streams of T items, and vice-versa, so that
+// data in files and network connections can be consumed by gbuf and gbufio types
+// through gio.Reader and gio.Writer implementations.
+//
+// The (de)serialization of each T item is performed by a Codec, with implementations
+// for fixed-size records (encoding/binary), JSON Lines, gob streams and CSV rows.
+//
+// Codecs also back SortStream, an external merge sort which spills sorted runs of
+// T items to temporary files.
+package codec
+
+import "io"
+
+// Codec creates the Decoder and Encoder for a stream of T items.
+type Codec[T any] interface {
+	// NewDecoder returns a Decoder reading T items from r.
+	NewDecoder(r io.Reader) Decoder[T]
+	// NewEncoder returns an Encoder writing T items to w.
+	NewEncoder(w io.Writer) Encoder[T]
+}
+
+// Decoder reads T items from an encoded stream, one at a time.
+//
+// Decode returns io.EOF when the stream ends cleanly, between T items.
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Encoder writes T items into an encoded stream, one at a time.
+//
+// Encoders may buffer their output; Flush writes any buffered data to the
+// underlying io.Writer.
+type Encoder[T any] interface {
+	Encode(item T) error
+	Flush() error
+}
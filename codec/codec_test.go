@@ -0,0 +1,164 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gbuf/gbufio"
+)
+
+type record struct {
+	ID    uint32
+	Value float64
+}
+
+func roundTrip[T any](t *testing.T, c Codec[T], items []T) {
+	t.Helper()
+
+	data := new(bytes.Buffer)
+	w := NewWriter[T](data, c)
+
+	n, err := w.Write(items)
+	require.NoError(t, err)
+	require.Equal(t, len(items), n)
+	require.NoError(t, w.Close())
+
+	// decode through a gbuf.Buffer, as a gio.Reader consumer
+	encoded := data.Bytes()
+	buf := gbuf.NewBuffer[T](nil)
+
+	m, err := buf.ReadFrom(NewReader[T](bytes.NewReader(encoded), c))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(items)), m)
+	require.Equal(t, items, buf.Value())
+
+	// decode through a DecodeWriter, as an io.Writer
+	out := gbuf.NewBuffer[T](nil)
+	dw := NewDecodeWriter[T](out, c)
+
+	_, err = io.Copy(dw, bytes.NewReader(encoded))
+	require.NoError(t, err)
+	require.NoError(t, dw.Close())
+	require.Equal(t, items, out.Value())
+}
+
+func TestCodecs(t *testing.T) {
+	records := []record{{1, 0.5}, {2, -1.25}, {3, 1e10}}
+
+	t.Run("BinaryBigEndian", func(t *testing.T) {
+		roundTrip(t, Binary[record](nil), records)
+	})
+
+	t.Run("BinaryLittleEndian", func(t *testing.T) {
+		roundTrip(t, Binary[record](binary.LittleEndian), records)
+	})
+
+	t.Run("JSONLines", func(t *testing.T) {
+		roundTrip(t, JSONLines[record](), records)
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		roundTrip(t, Gob[record](), records)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		roundTrip(t, CSV(';'), [][]string{{"a", "b"}, {"c;d", "e\nf"}, {"", "g"}})
+	})
+}
+
+func TestReader_Scanner(t *testing.T) {
+	input := "1\n2\n0\n3\n0\n4\n5\n"
+	s := gbufio.NewScanner[int](NewReader(strings.NewReader(input), JSONLines[int]()), gbufio.WithDelim(0))
+
+	var tokens [][]int
+
+	for s.Scan() {
+		tokens = append(tokens, append([]int(nil), s.Value()...))
+	}
+
+	require.NoError(t, s.Err())
+	require.Equal(t, [][]int{{1, 2}, {3}, {4, 5}}, tokens)
+}
+
+// wrappedEOFCodec decodes its items, then returns a wrapped io.EOF.
+type wrappedEOFCodec []int
+
+type wrappedEOFDecoder struct {
+	items []int
+}
+
+func (c wrappedEOFCodec) NewDecoder(io.Reader) Decoder[int] { return &wrappedEOFDecoder{items: c} }
+func (c wrappedEOFCodec) NewEncoder(io.Writer) Encoder[int] { return nil }
+
+func (d *wrappedEOFDecoder) Decode() (int, error) {
+	if len(d.items) == 0 {
+		return 0, fmt.Errorf("decoding item: %w", io.EOF)
+	}
+
+	item := d.items[0]
+	d.items = d.items[1:]
+
+	return item, nil
+}
+
+func TestReader_WriteToWrappedEOF(t *testing.T) {
+	buf := gbuf.NewBuffer[int](nil)
+	r := NewReader[int](nil, wrappedEOFCodec{1, 2, 3})
+
+	n, err := r.WriteTo(buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, []int{1, 2, 3}, buf.Value())
+}
+
+func TestReader_Errors(t *testing.T) {
+	t.Run("JSONLines", func(t *testing.T) {
+		r := NewReader(strings.NewReader("{\"ID\":1}\n{\"ID\":"), JSONLines[record]())
+		items := make([]record, 4)
+
+		n, err := r.Read(items)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		_, err = r.Read(items)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("BinaryPartialRecord", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(make([]byte, 6)), Binary[uint32](nil))
+
+		_, err := r.ReadItem()
+		require.NoError(t, err)
+
+		_, err = r.ReadItem()
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("BinaryUnsupportedType", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(make([]byte, 8)), Binary[int](nil))
+
+		_, err := r.ReadItem()
+		require.ErrorIs(t, err, gbuf.ErrBinaryUnsupportedType)
+	})
+
+	t.Run("DecodeWriter", func(t *testing.T) {
+		dw := NewDecodeWriter[record](gbuf.NewBuffer[record](nil), JSONLines[record]())
+
+		_, err := dw.Write([]byte("not json\n"))
+		if err == nil {
+			err = dw.Close()
+		}
+
+		var syntaxErr *json.SyntaxError
+		require.True(t, errors.As(err, &syntaxErr))
+		require.Error(t, dw.Close())
+	})
+}
@@ -0,0 +1,57 @@
+package codec
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvCodec struct {
+	comma rune
+}
+
+// CSV returns a Codec for CSV rows, as read and written by encoding/csv, where each
+// T item is a record's fields. If comma is zero, a comma (',') is used as the field
+// delimiter.
+func CSV(comma rune) Codec[[]string] {
+	if comma == 0 {
+		comma = ','
+	}
+
+	return csvCodec{comma: comma}
+}
+
+func (c csvCodec) NewDecoder(r io.Reader) Decoder[[]string] {
+	reader := csv.NewReader(r)
+	reader.Comma = c.comma
+
+	return csvDecoder{r: reader}
+}
+
+func (c csvCodec) NewEncoder(w io.Writer) Encoder[[]string] {
+	writer := csv.NewWriter(w)
+	writer.Comma = c.comma
+
+	return csvEncoder{w: writer}
+}
+
+type csvDecoder struct {
+	r *csv.Reader
+}
+
+func (d csvDecoder) Decode() ([]string, error) {
+	return d.r.Read()
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e csvEncoder) Encode(record []string) error {
+	return e.w.Write(record)
+}
+
+func (e csvEncoder) Flush() error {
+	e.w.Flush()
+
+	return e.w.Error()
+}
@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/gob"
+	"io"
+)
+
+type gobCodec[T any] struct{}
+
+// Gob returns a Codec for T items in a gob stream, as produced by a gob.Encoder.
+func Gob[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+func (gobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return &gobDecoder[T]{dec: gob.NewDecoder(r)}
+}
+
+func (gobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	bw := bufio.NewWriter(w)
+
+	return &gobEncoder[T]{w: bw, enc: gob.NewEncoder(bw)}
+}
+
+type gobDecoder[T any] struct {
+	dec *gob.Decoder
+}
+
+func (d *gobDecoder[T]) Decode() (item T, err error) {
+	err = d.dec.Decode(&item)
+
+	return item, err
+}
+
+type gobEncoder[T any] struct {
+	w   *bufio.Writer
+	enc *gob.Encoder
+}
+
+func (e *gobEncoder[T]) Encode(item T) error {
+	return e.enc.Encode(item)
+}
+
+func (e *gobEncoder[T]) Flush() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,50 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+type jsonLinesCodec[T any] struct{}
+
+// JSONLines returns a Codec for T items encoded as JSON values, one per line.
+//
+// Decoding accepts any whitespace between the JSON values.
+func JSONLines[T any]() Codec[T] {
+	return jsonLinesCodec[T]{}
+}
+
+func (jsonLinesCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return &jsonDecoder[T]{dec: json.NewDecoder(r)}
+}
+
+func (jsonLinesCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	bw := bufio.NewWriter(w)
+
+	return &jsonEncoder[T]{w: bw, enc: json.NewEncoder(bw)}
+}
+
+type jsonDecoder[T any] struct {
+	dec *json.Decoder
+}
+
+func (d *jsonDecoder[T]) Decode() (item T, err error) {
+	err = d.dec.Decode(&item)
+
+	return item, err
+}
+
+type jsonEncoder[T any] struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// Encode writes the JSON encoding of item, followed by a newline.
+func (e *jsonEncoder[T]) Encode(item T) error {
+	return e.enc.Encode(item)
+}
+
+func (e *jsonEncoder[T]) Flush() error {
+	return e.w.Flush()
+}
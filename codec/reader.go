@@ -0,0 +1,84 @@
+package codec
+
+import (
+	"errors"
+	"io"
+
+	"github.com/zalgonoise/gio"
+)
+
+// Reader implements the gio.Reader and gio.ItemReader interfaces, decoding T items
+// from an io.Reader with a Codec.
+type Reader[T any] struct {
+	dec Decoder[T]
+	err error // sticky decoding error
+}
+
+// NewReader returns a new Reader decoding T items from r, using codec c.
+func NewReader[T any](r io.Reader, c Codec[T]) *Reader[T] {
+	return &Reader[T]{dec: c.NewDecoder(r)}
+}
+
+// Read decodes up to len(p) T items into p. It returns the number of T items read
+// and any error encountered. Read only returns fewer than len(p) T items if the
+// Decoder returns an error; which is returned on the next call if n > 0.
+// At the end of the stream, Read returns 0, io.EOF.
+func (r *Reader[T]) Read(p []T) (n int, err error) {
+	for n < len(p) && r.err == nil {
+		p[n], r.err = r.dec.Decode()
+		if r.err != nil {
+			break
+		}
+
+		n++
+	}
+
+	if n > 0 {
+		return n, nil
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	return 0, r.err
+}
+
+// ReadItem decodes and returns a single T item.
+func (r *Reader[T]) ReadItem() (item T, err error) {
+	if r.err != nil {
+		return item, r.err
+	}
+
+	item, r.err = r.dec.Decode()
+
+	return item, r.err
+}
+
+// WriteTo implements the gio.WriterTo interface, decoding T items from the
+// stream and writing them to w, one at a time, until the stream ends or an error
+// occurs.
+func (r *Reader[T]) WriteTo(w gio.Writer[T]) (n int64, err error) {
+	item := make([]T, 1)
+
+	for {
+		if item[0], err = r.ReadItem(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+
+			return n, err
+		}
+
+		m, err := w.Write(item)
+		n += int64(m)
+
+		if err != nil {
+			return n, err
+		}
+
+		if m != 1 {
+			return n, io.ErrShortWrite
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package codec
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gio"
+)
+
+const (
+	// DefaultRunSize is the default maximum number of T items held in memory by a
+	// SortStream, for each sorted run.
+	DefaultRunSize = 1 << 16
+
+	// DefaultFanIn is the default maximum number of spilled runs merged at once
+	// by a SortStream.
+	DefaultFanIn = 64
+)
+
+// SortConfig holds the settings for a SortStream.
+type SortConfig struct {
+	runSize int
+	fanIn   int
+	dir     string
+}
+
+func defaultSortConfig() SortConfig {
+	return SortConfig{
+		runSize: DefaultRunSize,
+		fanIn:   DefaultFanIn,
+	}
+}
+
+// WithRunSize sets the memory budget of a SortStream, as the maximum number of
+// T items sorted in memory at once. Zero or negative values are ignored.
+func WithRunSize(n int) cfg.Option[SortConfig] {
+	if n <= 0 {
+		return cfg.NoOp[SortConfig]{}
+	}
+
+	return cfg.Register(func(c SortConfig) SortConfig {
+		c.runSize = n
+
+		return c
+	})
+}
+
+// WithFanIn sets the maximum number of spilled runs a SortStream merges at once,
+// which bounds the number of temporary files it keeps open. When there are more
+// runs, they are merged in batches into larger runs first, taking additional
+// passes over the data. Values lower than 2 are ignored.
+func WithFanIn(n int) cfg.Option[SortConfig] {
+	if n < 2 {
+		return cfg.NoOp[SortConfig]{}
+	}
+
+	return cfg.Register(func(c SortConfig) SortConfig {
+		c.fanIn = n
+
+		return c
+	})
+}
+
+// WithTempDir sets the directory where a SortStream creates its temporary
+// files. The default is os.TempDir.
+func WithTempDir(dir string) cfg.Option[SortConfig] {
+	return cfg.Register(func(c SortConfig) SortConfig {
+		c.dir = dir
+
+		return c
+	})
+}
+
+// SortStream is a gio.Reader returning the items of another gio.Reader in
+// sorted order, for streams that do not fit in memory (an external merge sort).
+//
+// The input is read in runs of up to a set number of T items (see WithRunSize),
+// each sorted in memory and spilled to a temporary file, encoded with a Codec.
+// The sorted runs are then merged back, with MergeSorted, as the SortStream is
+// read. The sort is stable.
+//
+// Spilled runs are closed once written, and at most a set number of them (see
+// WithFanIn) are open at once while merging; runs beyond that are merged in
+// batches into larger runs first.
+//
+// The client must call Close once done with the SortStream, removing its
+// temporary files.
+type SortStream[T any] struct {
+	less   func(a, b T) bool
+	codec  Codec[T]
+	config SortConfig
+
+	spilled int
+	temps   []string   // all temporary files not yet removed
+	files   []*os.File // the runs open for the final merge
+	merged  gio.Reader[T]
+	closed  bool
+}
+
+// NewSortStream reads all T items from r, up to io.EOF, sorting them according
+// to less. Sorted runs are spilled to temporary files encoded with codec c,
+// unless all items fit into a single run.
+//
+// If an error occurs, any temporary files are removed and the error is returned.
+func NewSortStream[T any](
+	r gio.Reader[T], less func(a, b T) bool, c Codec[T], opts ...cfg.Option[SortConfig],
+) (*SortStream[T], error) {
+	s := &SortStream[T]{
+		less:   less,
+		codec:  c,
+		config: cfg.Set(defaultSortConfig(), opts...),
+	}
+
+	if err := s.sort(r); err != nil {
+		return nil, errors.Join(err, s.Close())
+	}
+
+	return s, nil
+}
+
+func (s *SortStream[T]) sort(r gio.Reader[T]) error {
+	run := make([]T, s.config.runSize)
+
+	for {
+		n, err := gio.ReadFull(r, run)
+
+		switch {
+		case err == nil:
+			// a full run; there may be more items to read
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			// the last run is kept in memory
+			slices.SortStableFunc(run[:n], s.compare)
+
+			return s.merge(run[:n])
+		default:
+			return err
+		}
+
+		slices.SortStableFunc(run, s.compare)
+
+		if err = s.spill(run); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *SortStream[T]) compare(a, b T) int {
+	switch {
+	case s.less(a, b):
+		return -1
+	case s.less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// spill writes a sorted run into a new temporary file.
+func (s *SortStream[T]) spill(run []T) error {
+	s.spilled++
+
+	_, err := s.writeRun(gbuf.NewReader(run))
+
+	return err
+}
+
+// writeRun writes the items of r into a new temporary file, which is closed
+// once written, returning its name.
+func (s *SortStream[T]) writeRun(r gio.Reader[T]) (string, error) {
+	f, err := os.CreateTemp(s.config.dir, "gbuf-sort-*")
+	if err != nil {
+		return "", err
+	}
+
+	s.temps = append(s.temps, f.Name())
+
+	w := NewWriter(f, s.codec)
+
+	if _, err = gio.Copy[T](w, r); err == nil {
+		err = w.Flush()
+	}
+
+	if err = errors.Join(err, f.Close()); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// open opens the runs in names for reading, adding them to s.files.
+func (s *SortStream[T]) open(names []string) ([]gio.Reader[T], error) {
+	readers := make([]gio.Reader[T], 0, len(names)+1)
+
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+
+		s.files = append(s.files, f)
+		readers = append(readers, NewReader(f, s.codec))
+	}
+
+	return readers, nil
+}
+
+// closeFiles closes the open runs.
+func (s *SortStream[T]) closeFiles() error {
+	var errs []error
+
+	for _, f := range s.files {
+		errs = append(errs, f.Close())
+	}
+
+	s.files = nil
+
+	return errors.Join(errs...)
+}
+
+// mergeRuns merges the runs in names into a single new run, removing them.
+func (s *SortStream[T]) mergeRuns(names []string) (string, error) {
+	readers, err := s.open(names)
+	if err == nil {
+		var name string
+
+		if name, err = s.writeRun(gbuf.MergeSorted(s.less, readers...)); err == nil {
+			err = s.closeFiles()
+
+			for _, n := range names {
+				err = errors.Join(err, os.Remove(n))
+			}
+
+			s.temps = slices.DeleteFunc(s.temps, func(n string) bool { return slices.Contains(names, n) })
+
+			return name, err
+		}
+	}
+
+	return "", errors.Join(err, s.closeFiles())
+}
+
+// merge prepares the merged stream of the spilled runs, followed by the last
+// run, which remains in memory. Runs are merged in batches of consecutive runs
+// (keeping the sort stable) until no more than the fan-in remain.
+func (s *SortStream[T]) merge(last []T) error {
+	runs := slices.Clone(s.temps)
+
+	for len(runs) > s.config.fanIn {
+		next := make([]string, 0, len(runs)/s.config.fanIn+1)
+
+		for batch := range slices.Chunk(runs, s.config.fanIn) {
+			if len(batch) == 1 {
+				next = append(next, batch[0])
+
+				continue
+			}
+
+			name, err := s.mergeRuns(batch)
+			if err != nil {
+				return err
+			}
+
+			next = append(next, name)
+		}
+
+		runs = next
+	}
+
+	readers, err := s.open(runs)
+	if err != nil {
+		return err
+	}
+
+	if len(readers) == 0 {
+		s.merged = gbuf.NewReader(last)
+
+		return nil
+	}
+
+	readers = append(readers, gbuf.NewReader(last))
+	s.merged = gbuf.MergeSorted(s.less, readers...)
+
+	return nil
+}
+
+// Read implements the gio.Reader interface, reading the next len(p) sorted T
+// items. After Close, Read returns os.ErrClosed.
+func (s *SortStream[T]) Read(p []T) (n int, err error) {
+	if s.closed {
+		return 0, os.ErrClosed
+	}
+
+	return s.merged.Read(p)
+}
+
+// Runs returns the number of sorted runs spilled to temporary files, before
+// any batched merges.
+func (s *SortStream[T]) Runs() int { return s.spilled }
+
+// Close closes and removes the temporary files of the SortStream. Calling Close
+// more than once has no effect.
+func (s *SortStream[T]) Close() error {
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	s.merged = nil
+
+	errs := []error{s.closeFiles()}
+
+	for _, name := range s.temps {
+		errs = append(errs, os.Remove(name))
+	}
+
+	s.temps = nil
+
+	return errors.Join(errs...)
+}
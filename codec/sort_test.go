@@ -0,0 +1,170 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gio"
+)
+
+func byID(a, b record) bool { return a.ID < b.ID }
+
+// failingReader returns its items, and then err instead of io.EOF.
+type failingReader struct {
+	r   gio.Reader[record]
+	err error
+}
+
+func (r failingReader) Read(p []record) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil {
+		return n, r.err
+	}
+
+	return n, nil
+}
+
+func tempFiles(t *testing.T, dir string) []os.DirEntry {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	return entries
+}
+
+func TestSortStream(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		count int
+		opts  []cfg.Option[SortConfig]
+		runs  int
+	}{
+		{
+			name:  "Empty",
+			count: 0,
+			runs:  0,
+		},
+		{
+			name:  "InMemory",
+			count: 100,
+			runs:  0,
+		},
+		{
+			name:  "Spilled",
+			count: 1000,
+			opts:  []cfg.Option[SortConfig]{WithRunSize(64)},
+			runs:  15,
+		},
+		{
+			name:  "ExactRuns",
+			count: 256,
+			opts:  []cfg.Option[SortConfig]{WithRunSize(64)},
+			runs:  4,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			input := make([]record, testcase.count)
+			for i, id := range rand.Perm(testcase.count) {
+				input[i] = record{ID: uint32(id), Value: float64(i)}
+			}
+
+			opts := append([]cfg.Option[SortConfig]{WithTempDir(dir)}, testcase.opts...)
+
+			s, err := NewSortStream[record](gbuf.NewReader(input), byID, Binary[record](binary.LittleEndian), opts...)
+			require.NoError(t, err)
+			require.Equal(t, testcase.runs, s.Runs())
+			require.Len(t, tempFiles(t, dir), testcase.runs)
+
+			sorted, err := gio.ReadAll[record](s)
+			require.NoError(t, err)
+
+			wants := slices.Clone(input)
+			slices.SortFunc(wants, func(a, b record) int { return int(a.ID) - int(b.ID) })
+
+			if len(wants) == 0 {
+				require.Empty(t, sorted)
+			} else {
+				require.Equal(t, wants, sorted)
+			}
+
+			require.NoError(t, s.Close())
+			require.Empty(t, tempFiles(t, dir))
+			require.NoError(t, s.Close())
+
+			_, err = s.Read(make([]record, 1))
+			require.ErrorIs(t, err, os.ErrClosed)
+		})
+	}
+}
+
+func TestSortStream_Stable(t *testing.T) {
+	input := make([]record, 0, 300)
+	for i := range 300 {
+		input = append(input, record{ID: uint32(i % 3), Value: float64(i)})
+	}
+
+	s, err := NewSortStream[record](gbuf.NewReader(input), byID, JSONLines[record](),
+		WithRunSize(50), WithTempDir(t.TempDir()))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	sorted, err := gio.ReadAll[record](s)
+	require.NoError(t, err)
+	require.Len(t, sorted, len(input))
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].ID == sorted[i-1].ID {
+			require.Less(t, sorted[i-1].Value, sorted[i].Value)
+		}
+	}
+}
+
+func TestSortStream_FanIn(t *testing.T) {
+	dir := t.TempDir()
+
+	input := make([]record, 0, 1000)
+	for i := range 1000 {
+		input = append(input, record{ID: uint32(i*7919) % 10, Value: float64(i)})
+	}
+
+	// 15 spilled runs are merged in batches of 3: into 5 runs, and then into 2
+	s, err := NewSortStream[record](gbuf.NewReader(input), byID, Binary[record](nil),
+		WithRunSize(63), WithFanIn(3), WithTempDir(dir))
+	require.NoError(t, err)
+	require.Equal(t, 15, s.Runs())
+	require.Len(t, s.files, 2)
+	require.Len(t, tempFiles(t, dir), 2)
+
+	sorted, err := gio.ReadAll[record](s)
+	require.NoError(t, err)
+
+	wants := slices.Clone(input)
+	slices.SortStableFunc(wants, func(a, b record) int { return int(a.ID) - int(b.ID) })
+	require.Equal(t, wants, sorted)
+
+	require.NoError(t, s.Close())
+	require.Empty(t, tempFiles(t, dir))
+}
+
+func TestSortStream_Error(t *testing.T) {
+	errBroken := errors.New("broken input")
+	dir := t.TempDir()
+
+	input := make([]record, 200)
+	r := failingReader{r: gbuf.NewReader(input), err: errBroken}
+
+	_, err := NewSortStream[record](r, byID, Binary[record](nil), WithRunSize(64), WithTempDir(dir))
+	require.ErrorIs(t, err, errBroken)
+	require.Empty(t, tempFiles(t, dir))
+}
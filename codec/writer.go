@@ -0,0 +1,120 @@
+package codec
+
+import (
+	"io"
+
+	"github.com/zalgonoise/gio"
+)
+
+// Writer implements the gio.Writer and gio.ItemWriter interfaces, encoding T items
+// into an io.Writer with a Codec.
+//
+// Encoders may buffer their output; the client should call Flush (or Close)
+// once all T items are written.
+type Writer[T any] struct {
+	enc Encoder[T]
+	err error // sticky encoding error
+}
+
+// NewWriter returns a new Writer encoding T items into w, using codec c.
+func NewWriter[T any](w io.Writer, c Codec[T]) *Writer[T] {
+	return &Writer[T]{enc: c.NewEncoder(w)}
+}
+
+// Write encodes the T items in p. It returns the number of T items encoded,
+// and any error encountered.
+func (w *Writer[T]) Write(p []T) (n int, err error) {
+	for n < len(p) && w.err == nil {
+		if w.err = w.enc.Encode(p[n]); w.err != nil {
+			break
+		}
+
+		n++
+	}
+
+	return n, w.err
+}
+
+// WriteItem encodes a single T item.
+func (w *Writer[T]) WriteItem(item T) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.err = w.enc.Encode(item)
+
+	return w.err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer[T]) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.err = w.enc.Flush()
+
+	return w.err
+}
+
+// Close flushes the Writer. It does not close the underlying io.Writer.
+func (w *Writer[T]) Close() error {
+	return w.Flush()
+}
+
+// DecodeWriter implements the io.WriteCloser interface, decoding the bytes written
+// to it into T items with a Codec, and forwarding them to a gio.Writer.
+//
+// The decoding takes place in a separate goroutine, fed through an io.Pipe; so a
+// call to Write blocks until its bytes are consumed by the decoder. The client must
+// call Close once all data is written, to decode the remaining T items and release
+// the goroutine.
+type DecodeWriter[T any] struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+}
+
+// NewDecodeWriter returns a DecodeWriter decoding T items with codec c, writing
+// them to w.
+func NewDecodeWriter[T any](w gio.Writer[T], c Codec[T]) *DecodeWriter[T] {
+	pr, pw := io.Pipe()
+
+	d := &DecodeWriter[T]{
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(d.done)
+
+		_, d.err = NewReader(pr, c).WriteTo(w)
+
+		// unblock any pending or future writes, if decoding stopped early.
+		if d.err != nil {
+			_ = pr.CloseWithError(d.err)
+
+			return
+		}
+
+		_ = pr.Close()
+	}()
+
+	return d
+}
+
+// Write writes the bytes in p to the decoder. If decoding failed, Write returns
+// the decoding error.
+func (d *DecodeWriter[T]) Write(p []byte) (n int, err error) {
+	return d.pw.Write(p)
+}
+
+// Close signals the end of the encoded stream, waiting for all T items to be
+// decoded and written. It returns the first decoding or writing error, if any.
+func (d *DecodeWriter[T]) Close() error {
+	_ = d.pw.Close()
+
+	<-d.done
+
+	return d.err
+}
@@ -0,0 +1,127 @@
+package gbuf
+
+// DaryHeap applies the heap routines to a Heap with a configurable arity d,
+// where each element has up to d children instead of 2. Compared to a binary
+// heap, a d-ary heap is shallower, so that Push and Fix (when decreasing a
+// value) are cheaper, while Pop compares more children at each level. A 4-ary
+// heap is usually faster than a binary heap for decrease-key-heavy workloads.
+//
+// The Heap must not be modified other than through the DaryHeap's methods, or
+// Init must be called again.
+type DaryHeap[T any] struct {
+	h Heap[T]
+	d int
+}
+
+// NewDaryHeap returns a DaryHeap with arity d for h, establishing its heap
+// invariants. It panics if d is smaller than 2.
+// The complexity is O(n) where n = h.Len().
+func NewDaryHeap[T any](h Heap[T], d int) *DaryHeap[T] {
+	if d < 2 {
+		panic(ErrDaryHeapInvalidArity)
+	}
+
+	dh := &DaryHeap[T]{h: h, d: d}
+	dh.Init()
+
+	return dh
+}
+
+// Arity returns the maximum number of children of each element in the heap.
+func (dh *DaryHeap[T]) Arity() int { return dh.d }
+
+// Len returns the number of elements in the heap.
+func (dh *DaryHeap[T]) Len() int { return dh.h.Len() }
+
+// Init establishes the heap invariants. It may be called whenever the heap
+// invariants may have been invalidated.
+// The complexity is O(n) where n = dh.Len().
+func (dh *DaryHeap[T]) Init() {
+	n := dh.h.Len()
+	for i := (n - 2) / dh.d; i >= 0; i-- {
+		dh.down(i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n / log d) where n = dh.Len().
+func (dh *DaryHeap[T]) Push(x T) {
+	dh.h.Push(x)
+	dh.up(dh.h.Len() - 1)
+}
+
+// Pop removes and returns the minimum element (according to Less) from the heap.
+// The complexity is O(d * log n / log d) where n = dh.Len().
+// Pop is equivalent to Remove(0).
+func (dh *DaryHeap[T]) Pop() T {
+	n := dh.h.Len() - 1
+	dh.h.Swap(0, n)
+	dh.down(0, n)
+
+	return dh.h.Pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(d * log n / log d) where n = dh.Len().
+func (dh *DaryHeap[T]) Remove(i int) T {
+	n := dh.h.Len() - 1
+	if n != i {
+		dh.h.Swap(i, n)
+
+		if !dh.down(i, n) {
+			dh.up(i)
+		}
+	}
+
+	return dh.h.Pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// The complexity is O(log n / log d) if the value decreased, or
+// O(d * log n / log d) if it increased, where n = dh.Len().
+func (dh *DaryHeap[T]) Fix(i int) {
+	if !dh.down(i, dh.h.Len()) {
+		dh.up(i)
+	}
+}
+
+func (dh *DaryHeap[T]) up(j int) {
+	for {
+		i := (j - 1) / dh.d // parent
+		if i == j || !dh.h.Less(j, i) {
+			break
+		}
+
+		dh.h.Swap(i, j)
+		j = i
+	}
+}
+
+func (dh *DaryHeap[T]) down(i0, n int) bool {
+	i := i0
+
+	for {
+		j1 := dh.d*i + 1
+
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+
+		j := j1 // first child
+
+		for k := j1 + 1; k < j1+dh.d && k < n; k++ {
+			if dh.h.Less(k, j) {
+				j = k
+			}
+		}
+
+		if !dh.h.Less(j, i) {
+			break
+		}
+
+		dh.h.Swap(i, j)
+		i = j
+	}
+
+	return i > i0
+}
@@ -0,0 +1,74 @@
+package gbuf
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verifyDary checks the heap invariant of a d-ary heap.
+func verifyDary(t *testing.T, h *intHeap, d int) {
+	t.Helper()
+
+	for i := 1; i < h.Len(); i++ {
+		require.False(t, h.Less(i, (i-1)/d), "element %d is smaller than its parent", i)
+	}
+}
+
+func TestDaryHeap(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("Arity%d", d), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(d)))
+			init := make(intHeap, 0, 100)
+
+			for range 100 {
+				init = append(init, rng.Intn(1000))
+			}
+
+			h := &init
+			dh := NewDaryHeap[int](h, d)
+			require.Equal(t, d, dh.Arity())
+			verifyDary(t, h, d)
+
+			for range 100 {
+				dh.Push(rng.Intn(1000))
+			}
+
+			verifyDary(t, h, d)
+
+			// decrease and increase keys
+			for range 50 {
+				i := rng.Intn(dh.Len())
+				(*h)[i] = rng.Intn(2000) - 500
+				dh.Fix(i)
+			}
+
+			verifyDary(t, h, d)
+
+			for range 20 {
+				dh.Remove(rng.Intn(dh.Len()))
+			}
+
+			verifyDary(t, h, d)
+
+			wants := slices.Clone([]int(*h))
+			slices.Sort(wants)
+
+			popped := make([]int, 0, dh.Len())
+			for dh.Len() > 0 {
+				popped = append(popped, dh.Pop())
+			}
+
+			require.Equal(t, wants, popped)
+		})
+	}
+}
+
+func TestDaryHeap_InvalidArity(t *testing.T) {
+	require.PanicsWithValue(t, ErrDaryHeapInvalidArity, func() {
+		NewDaryHeap[int](&intHeap{}, 1)
+	})
+}
@@ -0,0 +1,151 @@
+package gbuf
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue holds T items that only become available once their scheduled
+// time has passed, such as retries, scheduled jobs or expiring leases. Items
+// are kept in a heap ordered by their scheduled time; items scheduled for the
+// same time are taken in the order they were scheduled.
+//
+// A DelayQueue is safe for concurrent use.
+//
+// The zero value is not usable; create one with NewDelayQueue.
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	h     lessHeap[delayEntry[T]]
+	clock Clock
+	seq   uint64
+
+	// scheduled is closed and replaced when an item is scheduled, waking any
+	// goroutines blocked in Take.
+	scheduled chan struct{}
+}
+
+type delayEntry[T any] struct {
+	at   time.Time
+	seq  uint64
+	item T
+}
+
+func delayEntryLess[T any](a, b delayEntry[T]) bool {
+	if a.at.Equal(b.at) {
+		return a.seq < b.seq
+	}
+
+	return a.at.Before(b.at)
+}
+
+// NewDelayQueue creates a DelayQueue using clock as its time source. If clock
+// is nil, the system clock is used.
+func NewDelayQueue[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	return &DelayQueue[T]{
+		h:         lessHeap[delayEntry[T]]{less: delayEntryLess[T]},
+		clock:     clock,
+		scheduled: make(chan struct{}),
+	}
+}
+
+// Len returns the number of items in the queue, ready or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.h.items)
+}
+
+// Schedule adds item to the queue, to become available at time at.
+// The complexity is O(log n) where n = q.Len().
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	Push[delayEntry[T]](&q.h, delayEntry[T]{at: at, seq: q.seq, item: item})
+	q.seq++
+	q.scheduled = notify(q.scheduled)
+}
+
+// Next returns the scheduled time of the next item in the queue, and whether
+// there is one.
+func (q *DelayQueue[T]) Next() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.h.items) == 0 {
+		return time.Time{}, false
+	}
+
+	return q.h.items[0].at, true
+}
+
+// TakeReady removes and returns all items scheduled at or before now, in the
+// order of their scheduled time. It returns nil if no items are ready.
+func (q *DelayQueue[T]) TakeReady(now time.Time) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var items []T
+
+	for len(q.h.items) > 0 && !q.h.items[0].at.After(now) {
+		items = append(items, Pop[delayEntry[T]](&q.h).item)
+	}
+
+	return items
+}
+
+// Take removes and returns the next item in the queue, blocking until its
+// scheduled time has passed (according to the queue's Clock), or until ctx is
+// done, in which case it returns the context's error. Items scheduled while
+// Take is blocked are taken into account.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+
+		var wait time.Duration
+
+		if len(q.h.items) > 0 {
+			wait = q.h.items[0].at.Sub(q.clock.Now())
+
+			if wait <= 0 {
+				item := Pop[delayEntry[T]](&q.h).item
+				q.mu.Unlock()
+
+				return item, nil
+			}
+		}
+
+		scheduled := q.scheduled
+		q.mu.Unlock()
+
+		var (
+			timer Timer
+			fired <-chan time.Time
+		)
+
+		if wait > 0 {
+			timer = q.clock.NewTimer(wait)
+			fired = timer.Chan()
+		}
+
+		select {
+		case <-fired:
+		case <-scheduled:
+		case <-ctx.Done():
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if err := ctx.Err(); err != nil {
+			return *new(T), err
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package gbuf
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a Clock whose time only moves with Advance.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	clock *manualClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the clock forward by d, firing any expired timers.
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	active := c.timers[:0]
+
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			active = append(active, t)
+
+			continue
+		}
+
+		t.c <- c.now
+	}
+
+	c.timers = active
+}
+
+// NewTicker is not used by the tests in this package.
+func (c *manualClock) NewTicker(time.Duration) Ticker {
+	panic("manualClock: tickers are not supported")
+}
+
+// Timers returns the number of active timers.
+func (c *manualClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (t *manualTimer) Chan() <-chan time.Time { return t.c }
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i := range t.clock.timers {
+		if t.clock.timers[i] == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestDelayQueue_TakeReady(t *testing.T) {
+	clock := newManualClock()
+	q := NewDelayQueue[string](clock)
+	now := clock.Now()
+
+	q.Schedule("c", now.Add(3*time.Second))
+	q.Schedule("a", now.Add(time.Second))
+	q.Schedule("b1", now.Add(2*time.Second))
+	q.Schedule("b2", now.Add(2*time.Second))
+	q.Schedule("late", now.Add(time.Hour))
+
+	require.Equal(t, 5, q.Len())
+	require.Nil(t, q.TakeReady(now))
+
+	next, ok := q.Next()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Second), next)
+
+	require.Equal(t, []string{"a", "b1", "b2"}, q.TakeReady(now.Add(2*time.Second)))
+	require.Equal(t, []string{"c"}, q.TakeReady(now.Add(time.Minute)))
+	require.Equal(t, 1, q.Len())
+	require.Equal(t, []string{"late"}, q.TakeReady(now.Add(time.Hour)))
+
+	_, ok = q.Next()
+	require.False(t, ok)
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	clock := newManualClock()
+	q := NewDelayQueue[string](clock)
+
+	// items already due are returned immediately
+	q.Schedule("due", clock.Now().Add(-time.Second))
+
+	item, err := q.Take(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "due", item)
+
+	result := make(chan string)
+
+	go func() {
+		item, err := q.Take(context.Background())
+		if err != nil {
+			item = err.Error()
+		}
+
+		result <- item
+	}()
+
+	q.Schedule("later", clock.Now().Add(time.Minute))
+	require.Eventually(t, func() bool { return clock.Timers() == 1 }, time.Second, time.Millisecond)
+
+	// an earlier item wakes the blocked Take, which then waits for it instead
+	q.Schedule("sooner", clock.Now().Add(time.Second))
+	require.Eventually(t, func() bool {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+
+		return len(clock.timers) == 1 && clock.timers[0].at.Equal(clock.now.Add(time.Second))
+	}, time.Second, time.Millisecond)
+
+	clock.Advance(time.Second)
+	require.Equal(t, "sooner", <-result)
+	require.Equal(t, 1, q.Len())
+}
+
+func TestDelayQueue_TakeContext(t *testing.T) {
+	clock := newManualClock()
+	q := NewDelayQueue[int](clock)
+	q.Schedule(1, clock.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := q.Take(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, q.Len())
+	require.Zero(t, clock.Timers())
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	q := NewDelayQueue[int](nil)
+	q.Schedule(1, time.Now().Add(5*time.Millisecond))
+
+	item, err := q.Take(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, item)
+}
@@ -0,0 +1,282 @@
+package gbuf
+
+import (
+	"io"
+	"iter"
+)
+
+const minDequeSize = 16 // minimum capacity when the Deque grows from empty
+
+// Deque is a double-ended queue backed by a circular slice, which grows as
+// needed. Unlike List, it does not allocate per item, and supports indexed
+// access in constant time.
+//
+// Deque implements gio.Reader and gio.Writer, reading from its front and
+// writing to its back, so it can be used as a FIFO queue of T items.
+//
+// The zero value for Deque is an empty deque ready to use.
+type Deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+// NewDeque creates an empty Deque with room for at least size T items before
+// it needs to grow.
+func NewDeque[T any](size int) *Deque[T] {
+	if size < 0 {
+		size = 0
+	}
+
+	return &Deque[T]{
+		items: make([]T, size),
+	}
+}
+
+// Len returns the number of items in the deque.
+func (d *Deque[T]) Len() int { return d.size }
+
+// Cap returns the length of the deque's underlying T item slice, that is, the
+// number of items it can hold before growing.
+func (d *Deque[T]) Cap() int { return len(d.items) }
+
+// index returns the position in d.items of the i-th item in the deque.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.items)
+}
+
+// grow doubles the capacity of the deque, unwrapping its items to the start of
+// the new slice.
+func (d *Deque[T]) grow() {
+	size := len(d.items) * 2
+	if size < minDequeSize {
+		size = minDequeSize
+	}
+
+	items := make([]T, size)
+
+	if d.size > 0 {
+		n := copy(items, d.items[d.head:])
+		copy(items[n:], d.items[:d.head])
+	}
+
+	d.items = items
+	d.head = 0
+}
+
+// PushFront inserts item at the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	if d.size == len(d.items) {
+		d.grow()
+	}
+
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = item
+	d.size++
+}
+
+// PushBack inserts item at the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	if d.size == len(d.items) {
+		d.grow()
+	}
+
+	d.items[d.index(d.size)] = item
+	d.size++
+}
+
+// PopFront removes and returns the item at the front of the deque. It returns
+// false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+
+	if d.size == 0 {
+		return zero, false
+	}
+
+	item := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+
+	return item, true
+}
+
+// PopBack removes and returns the item at the back of the deque. It returns
+// false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+
+	if d.size == 0 {
+		return zero, false
+	}
+
+	i := d.index(d.size - 1)
+	item := d.items[i]
+	d.items[i] = zero
+	d.size--
+
+	return item, true
+}
+
+// Front returns the item at the front of the deque, without removing it. It
+// returns false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.size == 0 {
+		return *new(T), false
+	}
+
+	return d.items[d.head], true
+}
+
+// Back returns the item at the back of the deque, without removing it. It
+// returns false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.size == 0 {
+		return *new(T), false
+	}
+
+	return d.items[d.index(d.size-1)], true
+}
+
+// At returns the i-th item in the deque, where zero is the front.
+// It panics with ErrDequeIndexOutOfBounds if i is not in [0, d.Len()).
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.size {
+		panic(ErrDequeIndexOutOfBounds)
+	}
+
+	return d.items[d.index(i)]
+}
+
+// Set replaces the i-th item in the deque, where zero is the front, with item.
+// It panics with ErrDequeIndexOutOfBounds if i is not in [0, d.Len()).
+func (d *Deque[T]) Set(i int, item T) {
+	if i < 0 || i >= d.size {
+		panic(ErrDequeIndexOutOfBounds)
+	}
+
+	d.items[d.index(i)] = item
+}
+
+// Rotate rotates the deque n steps to the right, moving the last n items to
+// the front. If n is negative, it rotates to the left, moving the first -n
+// items to the back. Rotating an empty deque has no effect.
+func (d *Deque[T]) Rotate(n int) {
+	if d.size <= 1 {
+		return
+	}
+
+	// normalize to a left rotation in [0, d.size)
+	n = (-n%d.size + d.size) % d.size
+	if n == 0 {
+		return
+	}
+
+	if d.size == len(d.items) {
+		// a full ring only needs its head moved
+		d.head = d.index(n)
+
+		return
+	}
+
+	// move the fewest items across the gap
+	if n <= d.size/2 {
+		for ; n > 0; n-- {
+			item, _ := d.PopFront()
+			d.PushBack(item)
+		}
+
+		return
+	}
+
+	for n = d.size - n; n > 0; n-- {
+		item, _ := d.PopBack()
+		d.PushFront(item)
+	}
+}
+
+// Clear removes all items from the deque, but it retains the underlying
+// storage for use by future pushes.
+func (d *Deque[T]) Clear() {
+	clear(d.items)
+	d.head = 0
+	d.size = 0
+}
+
+// Read implements the gio.Reader interface, popping the next len(p) T items
+// from the front of the deque, or until it is drained. The return value n is
+// the number of T items read. If the deque has no data to return, err is io.EOF
+// (unless len(p) is zero); otherwise it is nil.
+func (d *Deque[T]) Read(p []T) (n int, err error) {
+	if d.size == 0 {
+		if len(p) == 0 {
+			return 0, nil
+		}
+
+		return 0, io.EOF
+	}
+
+	for n < len(p) && d.size > 0 {
+		p[n], _ = d.PopFront()
+		n++
+	}
+
+	return n, nil
+}
+
+// ReadItem pops and returns the item at the front of the deque. If the deque
+// is empty, it returns error io.EOF.
+func (d *Deque[T]) ReadItem() (T, error) {
+	item, ok := d.PopFront()
+	if !ok {
+		return item, io.EOF
+	}
+
+	return item, nil
+}
+
+// Write implements the gio.Writer interface, pushing the contents of p to the
+// back of the deque, growing it as needed. The return value n is the length of
+// p; err is always nil.
+func (d *Deque[T]) Write(p []T) (n int, err error) {
+	for _, item := range p {
+		d.PushBack(item)
+	}
+
+	return len(p), nil
+}
+
+// WriteItem pushes item to the back of the deque. The returned error is always
+// nil, but is included to match gio.Writer's WriteItem.
+func (d *Deque[T]) WriteItem(item T) error {
+	d.PushBack(item)
+
+	return nil
+}
+
+// All returns an iterator over the index-value pairs of the deque, from front
+// to back. The behavior of All is undefined if the deque is modified during
+// iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(i, d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index-value pairs of the deque, from
+// back to front. The behavior of Backward is undefined if the deque is modified
+// during iteration.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package gbuf
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gio"
+)
+
+func dequeValues[T any](d *Deque[T]) []T {
+	values := make([]T, 0, d.Len())
+
+	for _, v := range d.All() {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func TestDeque_PushPop(t *testing.T) {
+	var d Deque[int]
+
+	_, ok := d.PopFront()
+	require.False(t, ok)
+	_, ok = d.PopBack()
+	require.False(t, ok)
+
+	for i := 0; i < 40; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+
+			continue
+		}
+
+		d.PushFront(i)
+	}
+
+	require.Equal(t, 40, d.Len())
+	require.GreaterOrEqual(t, d.Cap(), 40)
+
+	front, ok := d.Front()
+	require.True(t, ok)
+	require.Equal(t, 39, front)
+
+	back, ok := d.Back()
+	require.True(t, ok)
+	require.Equal(t, 38, back)
+
+	for want := 39; want > 0; want -= 2 {
+		item, ok := d.PopFront()
+		require.True(t, ok)
+		require.Equal(t, want, item)
+	}
+
+	for want := 38; want >= 0; want -= 2 {
+		item, ok := d.PopBack()
+		require.True(t, ok)
+		require.Equal(t, want, item)
+	}
+
+	require.Zero(t, d.Len())
+}
+
+func TestDeque_GrowWrapped(t *testing.T) {
+	d := NewDeque[int](4)
+
+	// wrap the head around the end of the underlying slice before growing
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	require.Equal(t, 4, d.Cap())
+
+	d.PushBack(4)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, dequeValues(d))
+	require.Equal(t, minDequeSize, d.Cap())
+}
+
+func TestDeque_AtSet(t *testing.T) {
+	d := NewDeque[string](0)
+	d.PushBack("b")
+	d.PushBack("c")
+	d.PushFront("a")
+
+	require.Equal(t, "a", d.At(0))
+	require.Equal(t, "c", d.At(2))
+
+	d.Set(1, "B")
+	require.Equal(t, []string{"a", "B", "c"}, dequeValues(d))
+
+	require.PanicsWithValue(t, ErrDequeIndexOutOfBounds, func() { d.At(3) })
+	require.PanicsWithValue(t, ErrDequeIndexOutOfBounds, func() { d.At(-1) })
+	require.PanicsWithValue(t, ErrDequeIndexOutOfBounds, func() { d.Set(3, "d") })
+}
+
+func TestDeque_Rotate(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		size  int
+		n     int
+		wants []int
+	}{
+		{name: "Zero", size: 0, n: 0, wants: []int{0, 1, 2, 3, 4}},
+		{name: "Right", size: 0, n: 2, wants: []int{3, 4, 0, 1, 2}},
+		{name: "Left", size: 0, n: -2, wants: []int{2, 3, 4, 0, 1}},
+		{name: "RightMostly", size: 0, n: 4, wants: []int{1, 2, 3, 4, 0}},
+		{name: "FullLength", size: 0, n: 5, wants: []int{0, 1, 2, 3, 4}},
+		{name: "Overflow", size: 0, n: 7, wants: []int{3, 4, 0, 1, 2}},
+		{name: "NegativeOverflow", size: 0, n: -11, wants: []int{1, 2, 3, 4, 0}},
+		{name: "Full/Right", size: 5, n: 1, wants: []int{4, 0, 1, 2, 3}},
+		{name: "Full/Left", size: 5, n: -1, wants: []int{1, 2, 3, 4, 0}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			d := NewDeque[int](testcase.size)
+			_, err := d.Write([]int{0, 1, 2, 3, 4})
+			require.NoError(t, err)
+
+			d.Rotate(testcase.n)
+
+			require.Equal(t, testcase.wants, dequeValues(d))
+		})
+	}
+}
+
+func TestDeque_Clear(t *testing.T) {
+	d := NewDeque[int](4)
+	d.PushBack(1)
+	d.PushFront(0)
+
+	d.Clear()
+	require.Zero(t, d.Len())
+	require.Equal(t, 4, d.Cap())
+	require.Equal(t, []int{0, 0, 0, 0}, d.items)
+
+	d.PushBack(7)
+	require.Equal(t, []int{7}, dequeValues(d))
+}
+
+func TestDeque_ReadWrite(t *testing.T) {
+	d := NewDeque[byte](2)
+
+	var w gio.Writer[byte] = d
+	var r gio.Reader[byte] = d
+
+	n, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.NoError(t, d.WriteItem('!'))
+
+	p := make([]byte, 4)
+
+	n, err = r.Read(p)
+	require.NoError(t, err)
+	require.Equal(t, "hell", string(p[:n]))
+
+	n, err = r.Read(p)
+	require.NoError(t, err)
+	require.Equal(t, "o!", string(p[:n]))
+
+	n, err = r.Read(nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	_, err = r.Read(p)
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = d.ReadItem()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestDeque_Backward(t *testing.T) {
+	d := NewDeque[int](3)
+	_, _ = d.Write([]int{1, 2, 3})
+
+	var values []int
+	for _, v := range d.Backward() {
+		values = append(values, v)
+	}
+
+	require.Equal(t, []int{3, 2, 1}, values)
+
+	slices.Reverse(values)
+	require.Equal(t, values, dequeValues(d))
+}
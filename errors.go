@@ -9,6 +9,11 @@ const (
 	ringBufferDomain = "gbuf/RingBuffer"
 	ringFilterDomain = "gbuf/RingFilter"
 	peekBufferDomain = "gbuf/PeekBuffer"
+	binaryDomain     = "gbuf/binary"
+	mergeDomain      = "gbuf/MergeSorted"
+	daryHeapDomain   = "gbuf/DaryHeap"
+	blockingPQDomain = "gbuf/BlockingPriorityQueue"
+	dequeDomain      = "gbuf/Deque"
 
 	ErrInvalid       = errs.Kind("invalid")
 	ErrPreviousOp    = errs.Kind("previous operation")
@@ -18,6 +23,8 @@ const (
 	ErrRepeat        = errs.Kind("Repeat")
 	ErrIndex         = errs.Kind("index")
 	ErrAtBeginning   = errs.Kind("at beginning of")
+	ErrUnsupported   = errs.Kind("unsupported")
+	ErrClosed        = errs.Kind("closed")
 
 	ErrWhence           = errs.Entity("whence")
 	ErrUnsuccessfulRead = errs.Entity("was not a successful read")
@@ -31,6 +38,13 @@ const (
 	ErrPosition         = errs.Entity("position")
 	ErrSlice            = errs.Entity("slice")
 	ErrOffset           = errs.Entity("offset")
+	ErrMark             = errs.Entity("mark")
+	ErrItemType         = errs.Entity("item type")
+	ErrHeader           = errs.Entity("header")
+	ErrLength           = errs.Entity("length")
+	ErrArity            = errs.Entity("arity")
+	ErrQueue            = errs.Entity("queue")
+	ErrSeparator        = errs.Entity("separator")
 )
 
 var (
@@ -41,6 +55,13 @@ var (
 	ErrRingBufferUnreadItem = errs.New(ringBufferDomain+".UnreadItem", ErrPreviousOp, ErrUnsuccessfulRead)
 	ErrRingFilterUnreadItem = errs.New(ringFilterDomain+".UnreadItem", ErrPreviousOp, ErrUnsuccessfulRead)
 
+	ErrBufferUnreadItems = errs.New(bufferDomain+".UnreadItems", ErrInvalid, ErrCount)
+	ErrBufferInvalidMark = errs.New(bufferDomain+".Rewind", ErrInvalid, ErrMark)
+
+	ErrBufferInvalidSeparator     = errs.New(bufferDomain+".ReadUntil", ErrInvalid, ErrSeparator)
+	ErrPeekBufferInvalidSeparator = errs.New(peekBufferDomain+".PeekUntil", ErrInvalid, ErrSeparator)
+	ErrReaderInvalidSeparator     = errs.New(readerDomain+".ReadUntil", ErrInvalid, ErrSeparator)
+
 	ErrReaderInvalidWhence     = errs.New(readerDomain+".Seek", ErrInvalid, ErrWhence)
 	ErrRingBufferInvalidWhence = errs.New(ringBufferDomain+".Seek", ErrInvalid, ErrWhence)
 	ErrRingFilterInvalidWhence = errs.New(ringFilterDomain+".Seek", ErrInvalid, ErrWhence)
@@ -54,13 +75,25 @@ var (
 	ErrBufferNegativeRead     = errs.New(bufferDomain, ErrNegativeCount, ErrReadOp)
 	ErrRingBufferNegativeRead = errs.New(ringBufferDomain, ErrNegativeCount, ErrReadOp)
 	ErrRingFilterNegativeRead = errs.New(ringFilterDomain, ErrNegativeCount, ErrReadOp)
+	ErrMergeNegativeRead      = errs.New(mergeDomain, ErrNegativeCount, ErrReadOp)
 
 	ErrBufferTooLarge = errs.New(bufferDomain, ErrTooMuchOf, ErrLargeSize)
 
 	ErrBufferNegativeCount     = errs.New(bufferDomain+".Grow", ErrNegative, ErrCount)
+	ErrBufferNegativeShrink    = errs.New(bufferDomain+".Shrink", ErrNegative, ErrCount)
 	ErrReaderNegativeCount     = errs.New(readerDomain+".WriteTo", ErrNegative, ErrCount)
 	ErrBufferInvalidWriteCount = errs.New(bufferDomain+".WriteTo", ErrInvalid, ErrWriteCount)
 
+	ErrBinaryUnsupportedType = errs.New(binaryDomain, ErrUnsupported, ErrItemType)
+	ErrBinaryInvalidHeader   = errs.New(binaryDomain, ErrInvalid, ErrHeader)
+	ErrBinaryInvalidLength   = errs.New(binaryDomain, ErrInvalid, ErrLength)
+	ErrBinaryTooLarge        = errs.New(binaryDomain, ErrTooMuchOf, ErrLargeSize)
+
+	ErrDaryHeapInvalidArity = errs.New(daryHeapDomain, ErrInvalid, ErrArity)
+
+	ErrBlockingPriorityQueueClosed = errs.New(blockingPQDomain, ErrClosed, ErrQueue)
+
 	ErrIndexOutOfBounds           = errs.New(libDomain, ErrIndex, ErrOutOfBounds)
 	ErrPeekBufferIndexOutOfBounds = errs.New(peekBufferDomain, ErrIndex, ErrOutOfBounds)
+	ErrDequeIndexOutOfBounds      = errs.New(dequeDomain, ErrIndex, ErrOutOfBounds)
 )
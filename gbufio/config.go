@@ -1,11 +1,25 @@
 package gbufio
 
-import "github.com/zalgonoise/cfg"
+import (
+	"time"
+
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
+)
 
 type Config[T any] struct {
-	size     int
-	delim    T
-	excluded *T
+	size      int
+	delim     T
+	delimSeq  []T
+	delimFunc func(T) bool
+	excluded  *T
+
+	maxFrameSize int
+	headerWidth  int
+
+	flushInterval time.Duration
+	flushOn       func(T) bool
+	clock         gbuf.Clock
 }
 
 func defaultConfig[T any]() Config[T] {
@@ -30,6 +44,38 @@ func WithDelim[T comparable](delim T) cfg.Option[Config[T]] {
 	})
 }
 
+// WithDelimSeq sets a multi-item delimiter (such as a CRLF sequence) used by a ComparableReader's ReadLine
+// and by a Scanner's default split function, instead of the single-item delimiter set with WithDelim.
+// Empty sequences are ignored.
+func WithDelimSeq[T comparable](delim ...T) cfg.Option[Config[T]] {
+	if len(delim) == 0 {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	delimSeq := make([]T, len(delim))
+	copy(delimSeq, delim)
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.delimSeq = delimSeq
+
+		return c
+	})
+}
+
+// WithDelimFunc sets a delimiter predicate, used by a ScannerFunc's default split function, for types
+// that do not allow comparisons.
+func WithDelimFunc[T any](delim func(T) bool) cfg.Option[Config[T]] {
+	if delim == nil {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.delimFunc = delim
+
+		return c
+	})
+}
+
 func WithExcluded[T comparable](excluded *T) cfg.Option[Config[T]] {
 	if excluded == nil {
 		return cfg.NoOp[Config[T]]{}
@@ -41,3 +87,70 @@ func WithExcluded[T comparable](excluded *T) cfg.Option[Config[T]] {
 		return c
 	})
 }
+
+// WithMaxFrameSize sets the maximum number of items in a frame's payload, for a
+// FrameReader or a FrameWriter. Zero or negative values are ignored.
+func WithMaxFrameSize[T any](size int) cfg.Option[Config[T]] {
+	if size <= 0 {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.maxFrameSize = size
+
+		return c
+	})
+}
+
+// WithFixedHeader configures a FrameReader or a FrameWriter to use a fixed-width,
+// big-endian length header of `width` items (from 1 to 8), instead of a uvarint.
+// Values outside of this range are ignored.
+func WithFixedHeader[T any](width int) cfg.Option[Config[T]] {
+	if width < 1 || width > maxFixedHeaderWidth {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.headerWidth = width
+
+		return c
+	})
+}
+
+// WithFlushInterval configures a Writer to flush its buffered data every d, from a background goroutine,
+// so that items are not held indefinitely during quiet periods. Such a Writer must be closed with Close.
+// Zero or negative durations are ignored.
+func WithFlushInterval[T any](d time.Duration) cfg.Option[Config[T]] {
+	if d <= 0 {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.flushInterval = d
+
+		return c
+	})
+}
+
+// WithFlushOn configures a Writer to flush its buffered data whenever delim is written, like a line-buffered
+// output stream.
+func WithFlushOn[T comparable](delim T) cfg.Option[Config[T]] {
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.flushOn = func(item T) bool { return item == delim }
+
+		return c
+	})
+}
+
+// WithClock sets the Clock used by a Writer configured with WithFlushInterval. A nil Clock is ignored.
+func WithClock[T any](clock gbuf.Clock) cfg.Option[Config[T]] {
+	if clock == nil {
+		return cfg.NoOp[Config[T]]{}
+	}
+
+	return cfg.Register[Config[T]](func(c Config[T]) Config[T] {
+		c.clock = clock
+
+		return c
+	})
+}
@@ -0,0 +1,239 @@
+package gbufio
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"time"
+)
+
+// ReadDeadliner is implemented by readers that support read deadlines, such as
+// a net.Conn. Context-aware reads use it to interrupt a blocked Read when the
+// context is done.
+type ReadDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// WriteDeadliner is implemented by writers that support write deadlines, such
+// as a net.Conn. Context-aware writes use it to interrupt a blocked Write when
+// the context is done.
+type WriteDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// aLongTimeAgo is a non-zero time, far in the past, used to interrupt blocked
+// I/O immediately.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// handoff holds the result of an underlying Read or Write performed in a
+// separate goroutine.
+type handoff[T any] struct {
+	items []T
+	n     int
+	err   error
+}
+
+// withDeadline runs call, interrupting it by setting a deadline in the past
+// once ctx is done. Any deadline set by the caller is left in place, unless the
+// call is interrupted, in which case the deadline is cleared afterwards, as the
+// caller's deadline cannot be retrieved to restore it. Deadline errors caused
+// by the interruption are replaced with the context's error.
+func withDeadline(ctx context.Context, setDeadline func(time.Time) error, call func() (int, error)) (int, error) {
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(interrupted)
+		_ = setDeadline(aLongTimeAgo)
+	})
+
+	n, err := call()
+
+	if stop() {
+		return n, err
+	}
+
+	<-interrupted
+	// Clear the interrupting deadline, so that it does not affect later calls.
+	_ = setDeadline(time.Time{})
+
+	if err != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+		err = ctx.Err()
+	}
+
+	return n, err
+}
+
+// readContext performs a single Read on the underlying reader, returning early
+// with the context's error once ctx is done.
+//
+// If the underlying reader is a ReadDeadliner, a blocked Read is interrupted
+// with a deadline. Otherwise, the Read is performed in a separate goroutine
+// and, if ctx is done first, its result is kept to be returned by the next
+// read from the underlying reader.
+func (b *Reader[T]) readContext(ctx context.Context, p []T) (int, error) {
+	if b.pending != nil {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case h := <-b.pending:
+			if h.n < 0 {
+				panic(errNegativeRead)
+			}
+			h.items = h.items[:h.n]
+			b.pending = nil
+			b.carry = &h
+		}
+	}
+
+	if b.carry != nil {
+		n := copy(p, b.carry.items)
+		b.carry.items = b.carry.items[n:]
+		if len(b.carry.items) > 0 {
+			return n, nil
+		}
+		err := b.carry.err
+		b.carry = nil
+		return n, err
+	}
+
+	if ctx.Done() == nil {
+		return b.rd.Read(p)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	rd := b.rd
+	if d, ok := rd.(ReadDeadliner); ok {
+		return withDeadline(ctx, d.SetReadDeadline, func() (int, error) {
+			return rd.Read(p)
+		})
+	}
+
+	// Read into a separate buffer, as p must not be written to after returning.
+	scratch := make([]T, len(p))
+	ch := make(chan handoff[T], 1)
+	go func() {
+		n, err := rd.Read(scratch)
+		ch <- handoff[T]{items: scratch, n: n, err: err}
+	}()
+
+	select {
+	case h := <-ch:
+		if h.n < 0 {
+			return h.n, h.err
+		}
+		return copy(p, h.items[:h.n]), h.err
+	case <-ctx.Done():
+		b.pending = ch
+		return 0, ctx.Err()
+	}
+}
+
+// ReadContext is like Read, but returns early with the context's error once
+// ctx is done. Items read from the underlying reader after ctx is done are not
+// lost; they are returned by subsequent reads.
+//
+// If the underlying reader is a ReadDeadliner, its read deadline is left as set
+// by the caller, unless ctx is done during the read: the read is then
+// interrupted with a past deadline, and the read deadline is cleared.
+func (b *Reader[T]) ReadContext(ctx context.Context, p []T) (n int, err error) {
+	return b.read(ctx, p)
+}
+
+// ReadItemContext is like ReadItem, but returns early with the context's error
+// once ctx is done.
+func (b *Reader[T]) ReadItemContext(ctx context.Context) (T, error) {
+	return b.readItem(ctx)
+}
+
+// PeekContext is like Peek, but returns early with the context's error once
+// ctx is done, along with any items already buffered.
+func (b *Reader[T]) PeekContext(ctx context.Context, n int) ([]T, error) {
+	return b.peek(ctx, n)
+}
+
+// writeContext performs a single Write on the underlying writer, returning
+// early with the context's error once ctx is done.
+//
+// If the underlying writer is a WriteDeadliner, a blocked Write is interrupted
+// with a deadline. Otherwise, the Write is performed from a copy of p in a
+// separate goroutine, which may still complete after returning; it is tracked
+// in b.inflight, so that no other write reaches the underlying writer until it
+// does.
+func (b *Writer[T]) writeContext(ctx context.Context, p []T) (int, error) {
+	b.wait()
+
+	if ctx.Done() == nil {
+		return b.wr.Write(p)
+	}
+
+	wr := b.wr
+	if d, ok := wr.(WriteDeadliner); ok {
+		return withDeadline(ctx, d.SetWriteDeadline, func() (int, error) {
+			return wr.Write(p)
+		})
+	}
+
+	// Write from a copy, as the buffer may be reused after returning.
+	data := slices.Clone(p)
+	ch := make(chan handoff[T], 1)
+	inflight := make(chan struct{})
+	go func() {
+		defer close(inflight)
+		n, err := wr.Write(data)
+		ch <- handoff[T]{n: n, err: err}
+	}()
+
+	select {
+	case h := <-ch:
+		return h.n, h.err
+	case <-ctx.Done():
+		b.inflight = inflight
+		return 0, ctx.Err()
+	}
+}
+
+// wait blocks until any write abandoned by writeContext returns.
+func (b *Writer[T]) wait() {
+	if b.inflight != nil {
+		<-b.inflight
+		b.inflight = nil
+	}
+}
+
+// WriteContext is like Write, but returns early with the context's error once
+// ctx is done. If ctx is done while writing to the underlying writer, the
+// amount of data written is unknown and, like any other write error, the
+// context's error is returned by all subsequent writes and flushes.
+//
+// If the underlying writer is a WriteDeadliner, its write deadline is left as
+// set by the caller, unless ctx is done during the write: the write is then
+// interrupted with a past deadline, and the write deadline is cleared.
+func (b *Writer[T]) WriteContext(ctx context.Context, p []T) (nn int, err error) {
+	if err = ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.lock()
+	defer b.unlock()
+
+	return b.write(ctx, p)
+}
+
+// FlushContext is like Flush, but returns early with the context's error once
+// ctx is done. If ctx is done while writing to the underlying writer, the
+// amount of data written is unknown and, like any other write error, the
+// context's error is returned by all subsequent writes and flushes.
+//
+// Write deadlines are handled as in WriteContext.
+func (b *Writer[T]) FlushContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.lock()
+	defer b.unlock()
+
+	return b.flushContext(ctx)
+}
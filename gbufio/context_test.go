@@ -0,0 +1,276 @@
+package gbufio
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// chanReader blocks on Read until a chunk is sent on its channel.
+type chanReader struct {
+	ch chan []byte
+}
+
+func (r chanReader) Read(p []byte) (int, error) {
+	chunk, ok := <-r.ch
+	if !ok {
+		return 0, io.EOF
+	}
+
+	return copy(p, chunk), nil
+}
+
+// chanWriter blocks on Write until it is released.
+type chanWriter struct {
+	release chan struct{}
+}
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	<-w.release
+
+	return len(p), nil
+}
+
+// deadlineReader is a ReadDeadliner recording the read deadlines set on it. Its
+// Read blocks until the deadline is in the past, or returns a queued chunk.
+type deadlineReader struct {
+	mu        sync.Mutex
+	deadline  time.Time
+	deadlines []time.Time
+	ch        chan []byte
+	expired   chan struct{}
+}
+
+func newDeadlineReader() *deadlineReader {
+	return &deadlineReader{ch: make(chan []byte, 1), expired: make(chan struct{}, 1)}
+}
+
+func (r *deadlineReader) SetReadDeadline(t time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deadline = t
+	r.deadlines = append(r.deadlines, t)
+
+	if !t.IsZero() && t.Before(time.Now()) {
+		select {
+		case r.expired <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (r *deadlineReader) Deadline() (time.Time, []time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deadline, slices.Clone(r.deadlines)
+}
+
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	select {
+	case chunk := <-r.ch:
+		return copy(p, chunk), nil
+	case <-r.expired:
+		return 0, os.ErrDeadlineExceeded
+	}
+}
+
+func TestReader_ReadContext_CallerDeadline(t *testing.T) {
+	src := newDeadlineReader()
+	callerDeadline := time.Now().Add(time.Hour)
+	require.NoError(t, src.SetReadDeadline(callerDeadline))
+
+	r := NewReader[byte](src, WithSize[byte](minReadBufferSize))
+	p := make([]byte, 8)
+
+	// a completed read keeps the caller's deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	src.ch <- []byte("hello")
+
+	n, err := r.ReadContext(ctx, p)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(p[:n]))
+
+	deadline, deadlines := src.Deadline()
+	require.Equal(t, callerDeadline, deadline)
+	require.Len(t, deadlines, 1)
+
+	// an interrupted read clears the deadline it set
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = r.ReadContext(ctx, p)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	deadline, deadlines = src.Deadline()
+	require.True(t, deadline.IsZero())
+	require.Len(t, deadlines, 3)
+	require.Equal(t, aLongTimeAgo, deadlines[1])
+}
+
+func TestReader_ReadContext_Handoff(t *testing.T) {
+	src := chanReader{ch: make(chan []byte)}
+	r := NewReader[byte](src, WithSize[byte](minReadBufferSize))
+	p := make([]byte, 8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := r.ReadContext(ctx, p)
+	require.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = r.ReadItemContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the interrupted read still completes, and its items are not lost
+	src.ch <- []byte("hello")
+	close(src.ch)
+
+	n, err := r.Read(p)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(p[:n]))
+
+	_, err = r.ReadContext(context.Background(), p)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReader_PeekContext_Handoff(t *testing.T) {
+	src := chanReader{ch: make(chan []byte, 1)}
+	r := NewReader[byte](src, WithSize[byte](minReadBufferSize))
+
+	src.ch <- []byte("ab")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	peek, err := r.PeekContext(ctx, 4)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, "ab", string(peek))
+
+	src.ch <- []byte("cd")
+
+	peek, err = r.Peek(4)
+	require.NoError(t, err)
+	require.Equal(t, "abcd", string(peek))
+}
+
+func TestReader_ReadContext_Deadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	r := NewReader[byte](client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := r.ReadItemContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = r.PeekContext(ctx, 1)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the deadline is cleared once the call returns
+	go func() { _, _ = server.Write([]byte("x")) }()
+
+	item, err := r.ReadItem()
+	require.NoError(t, err)
+	require.Equal(t, byte('x'), item)
+}
+
+func TestWriter_FlushContext_Deadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	w := NewWriter[byte](client)
+
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// a done context returns before writing, and does not break the Writer
+	require.ErrorIs(t, w.FlushContext(ctx), context.Canceled)
+	_, err = w.WriteContext(ctx, []byte("!"))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 5, w.Buffered())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, w.FlushContext(ctx), context.DeadlineExceeded)
+	// interrupted writes are sticky errors
+	require.ErrorIs(t, w.Flush(), context.DeadlineExceeded)
+}
+
+func TestWriter_WriteContext_Handoff(t *testing.T) {
+	dst := chanWriter{release: make(chan struct{})}
+	defer close(dst.release)
+
+	w := NewWriter[byte](dst, WithSize[byte](4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// larger than the buffer, so it is written directly
+	_, err := w.WriteContext(ctx, []byte("hello world"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, w.WriteItem('!'), context.DeadlineExceeded)
+}
+
+// countingWriter blocks on Write until it is released, counting the writes that
+// returned.
+type countingWriter struct {
+	release chan struct{}
+	written atomic.Int32
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.written.Add(1)
+
+	return len(p), nil
+}
+
+func TestWriter_Reset_Inflight(t *testing.T) {
+	dst := &countingWriter{release: make(chan struct{})}
+	w := NewWriter[byte](dst, WithSize[byte](4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := w.WriteContext(ctx, []byte("hello world"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, dst.written.Load())
+
+	go close(dst.release)
+
+	// Reset waits for the abandoned write, so it never overlaps later writes
+	w.Reset(dst)
+	require.Equal(t, int32(1), dst.written.Load())
+
+	_, err = w.WriteContext(context.Background(), []byte("again"))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), dst.written.Load())
+}
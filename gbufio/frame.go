@@ -0,0 +1,252 @@
+package gbufio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gio"
+)
+
+// DefaultMaxFrameSize is the default maximum number of items in a frame's payload,
+// for a FrameReader and a FrameWriter.
+const DefaultMaxFrameSize = 1 << 20
+
+const maxFixedHeaderWidth = 8
+
+var (
+	ErrFrameTooLarge      = errors.New("gbufio: frame too large")
+	ErrInvalidFrameHeader = errors.New("gbufio: invalid frame header")
+)
+
+// Integer is a constraint for the item types that can be framed by a FrameReader
+// and a FrameWriter. The frame's length header is encoded as a sequence of byte
+// values (0 to 255), one per item, so for byte streams the framing is the same as
+// a uvarint or fixed-width big-endian length prefix.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// FrameWriter writes length-prefixed frames of T items to a Writer. Each frame
+// is composed of a length header, as a uvarint (by default) or as a fixed-width
+// big-endian integer (via WithFixedHeader), followed by the frame's payload.
+//
+// As with a Writer, the client should call the Flush method once all frames
+// have been written.
+type FrameWriter[T Integer] struct {
+	w *Writer[T]
+
+	header  []T
+	width   int
+	maxSize int
+}
+
+// NewFrameWriter returns a new FrameWriter writing frames to w.
+//
+// The FrameWriter can be configured with a set buffer size (via WithSize), a
+// maximum frame size (via WithMaxFrameSize) and a fixed-width length header
+// (via WithFixedHeader).
+func NewFrameWriter[T Integer](w gio.Writer[T], opts ...cfg.Option[Config[T]]) *FrameWriter[T] {
+	config := cfg.Set(defaultConfig[T](), opts...)
+
+	return &FrameWriter[T]{
+		w:       newWriter(w, config.size),
+		header:  make([]T, 0, binary.MaxVarintLen64),
+		width:   config.headerWidth,
+		maxSize: maxFrameSize(config.maxFrameSize),
+	}
+}
+
+// WriteFrame writes the items in p as a single frame. It returns ErrFrameTooLarge
+// if len(p) exceeds the maximum frame size, without writing any data.
+func (f *FrameWriter[T]) WriteFrame(p []T) error {
+	if len(p) > f.maxSize || f.width > 0 && f.width < maxFixedHeaderWidth && uint64(len(p)) >= 1<<(8*f.width) {
+		return ErrFrameTooLarge
+	}
+
+	var scratch [binary.MaxVarintLen64]byte
+
+	header := scratch[:0]
+
+	if f.width > 0 {
+		header = binary.BigEndian.AppendUint64(header, uint64(len(p)))[maxFixedHeaderWidth-f.width:]
+	} else {
+		header = binary.AppendUvarint(header, uint64(len(p)))
+	}
+
+	f.header = f.header[:0]
+	for i := range header {
+		f.header = append(f.header, T(header[i]))
+	}
+
+	if _, err := f.w.Write(f.header); err != nil {
+		return err
+	}
+
+	_, err := f.w.Write(p)
+
+	return err
+}
+
+// Flush writes any buffered frames to the underlying gio.Writer.
+func (f *FrameWriter[T]) Flush() error { return f.w.Flush() }
+
+// Reset discards any unflushed frames, resets any error, and switches the
+// FrameWriter to write its output to w.
+func (f *FrameWriter[T]) Reset(w gio.Writer[T]) { f.w.Reset(w) }
+
+// FrameReader reads length-prefixed frames of T items from a Reader, as written
+// by a FrameWriter with the same configuration.
+type FrameReader[T Integer] struct {
+	r *Reader[T]
+
+	frame   []T
+	width   int
+	maxSize int
+}
+
+// NewFrameReader returns a new FrameReader reading frames from rd.
+//
+// The FrameReader can be configured with a set buffer size (via WithSize), a
+// maximum frame size (via WithMaxFrameSize) and a fixed-width length header
+// (via WithFixedHeader).
+func NewFrameReader[T Integer](rd gio.Reader[T], opts ...cfg.Option[Config[T]]) *FrameReader[T] {
+	config := cfg.Set(defaultConfig[T](), opts...)
+
+	return &FrameReader[T]{
+		r:       newReader(rd, config.size),
+		width:   config.headerWidth,
+		maxSize: maxFrameSize(config.maxFrameSize),
+	}
+}
+
+// ReadFrame reads the next frame, returning its payload. The returned slice is
+// reused by the FrameReader, and is only valid until the next call to ReadFrame.
+//
+// Frames may span any number of fills of the underlying buffer. ReadFrame returns
+// io.EOF if the stream ends cleanly before a frame, io.ErrUnexpectedEOF if it ends
+// within a frame, ErrInvalidFrameHeader if the length header is malformed, and
+// ErrFrameTooLarge if the frame exceeds the maximum frame size. An oversized
+// frame's payload is discarded, so the next call to ReadFrame reads the frame
+// that follows it; if the stream ends within it, ErrFrameTooLarge is joined with
+// io.ErrUnexpectedEOF.
+func (f *FrameReader[T]) ReadFrame() ([]T, error) {
+	size, err := f.readHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	if size > uint64(f.maxSize) {
+		if err = f.discard(size); err != nil {
+			return nil, errors.Join(ErrFrameTooLarge, err)
+		}
+
+		return nil, ErrFrameTooLarge
+	}
+
+	if cap(f.frame) < int(size) {
+		f.frame = make([]T, size)
+	}
+
+	f.frame = f.frame[:size]
+
+	if _, err = gio.ReadFull[T](f.r, f.frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return f.frame, nil
+}
+
+// discard skips the size items of a frame's payload.
+func (f *FrameReader[T]) discard(size uint64) error {
+	for size > 0 {
+		n := int(min(size, math.MaxInt32))
+
+		if _, err := f.r.Discard(n); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return err
+		}
+
+		size -= uint64(n)
+	}
+
+	return nil
+}
+
+// Reset discards any buffered data, resets all state, and switches the
+// FrameReader to read from rd.
+func (f *FrameReader[T]) Reset(rd gio.Reader[T]) { f.r.Reset(rd) }
+
+// readHeader reads and decodes a frame's length header.
+func (f *FrameReader[T]) readHeader() (size uint64, err error) {
+	if f.width > 0 {
+		for i := 0; i < f.width; i++ {
+			b, err := f.readHeaderItem(i)
+			if err != nil {
+				return 0, err
+			}
+
+			size = size<<8 | uint64(b)
+		}
+
+		return size, nil
+	}
+
+	var shift uint
+
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := f.readHeaderItem(i)
+		if err != nil {
+			return 0, err
+		}
+
+		if b < 0x80 {
+			if i == binary.MaxVarintLen64-1 && b > 1 {
+				return 0, ErrInvalidFrameHeader // overflow
+			}
+
+			return size | uint64(b)<<shift, nil
+		}
+
+		size |= uint64(b&0x7f) << shift
+		shift += 7
+	}
+
+	return 0, ErrInvalidFrameHeader // overflow
+}
+
+// readHeaderItem reads the i-th item of a length header, as a byte.
+func (f *FrameReader[T]) readHeaderItem(i int) (byte, error) {
+	item, err := f.r.ReadItem()
+	if err != nil {
+		if i > 0 && errors.Is(err, io.EOF) {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		return 0, err
+	}
+
+	if item < 0 || uint64(item) > 0xff {
+		return 0, ErrInvalidFrameHeader
+	}
+
+	return byte(item), nil
+}
+
+func maxFrameSize(size int) int {
+	if size <= 0 {
+		return DefaultMaxFrameSize
+	}
+
+	return size
+}
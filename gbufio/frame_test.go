@@ -0,0 +1,170 @@
+package gbufio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
+)
+
+func TestFrameReader_ReadFrame(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		frames [][]byte
+		opts   []cfg.Option[Config[byte]]
+	}{
+		{
+			name:   "Uvarint",
+			frames: [][]byte{[]byte("hello"), {}, []byte("world")},
+		},
+		{
+			name:   "UvarintMultiByteHeader",
+			frames: [][]byte{bytes.Repeat([]byte("x"), 300), []byte("y")},
+		},
+		{
+			name:   "FixedHeader",
+			frames: [][]byte{[]byte("hello"), bytes.Repeat([]byte("z"), 1000)},
+			opts:   []cfg.Option[Config[byte]]{WithFixedHeader[byte](4)},
+		},
+		{
+			// frames straddle several fills of the smallest reader buffer
+			name:   "SmallBuffer",
+			frames: [][]byte{bytes.Repeat([]byte("a"), 40), bytes.Repeat([]byte("b"), 17)},
+			opts:   []cfg.Option[Config[byte]]{WithSize[byte](minReadBufferSize)},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			buf := gbuf.NewBuffer[byte](nil)
+			w := NewFrameWriter[byte](buf, testcase.opts...)
+
+			for _, frame := range testcase.frames {
+				require.NoError(t, w.WriteFrame(frame))
+			}
+
+			require.NoError(t, w.Flush())
+
+			r := NewFrameReader[byte](buf, testcase.opts...)
+
+			for _, frame := range testcase.frames {
+				got, err := r.ReadFrame()
+				require.NoError(t, err)
+				require.Equal(t, string(frame), string(got))
+			}
+
+			_, err := r.ReadFrame()
+			require.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestFrameReader_Errors(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input []uint16
+		opts  []cfg.Option[Config[uint16]]
+		err   error
+	}{
+		{
+			name:  "TooLarge",
+			input: []uint16{10, 1, 2, 3},
+			opts:  []cfg.Option[Config[uint16]]{WithMaxFrameSize[uint16](4)},
+			err:   ErrFrameTooLarge,
+		},
+		{
+			name:  "TooLargeTruncated",
+			input: []uint16{10, 1, 2, 3},
+			opts:  []cfg.Option[Config[uint16]]{WithMaxFrameSize[uint16](2)},
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "NonByteHeader",
+			input: []uint16{0x100, 1},
+			err:   ErrInvalidFrameHeader,
+		},
+		{
+			name:  "TruncatedHeader",
+			input: []uint16{0x80},
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "TruncatedPayload",
+			input: []uint16{3, 1, 2},
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "Overflow",
+			input: []uint16{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+			err:   ErrInvalidFrameHeader,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := NewFrameReader[uint16](gbuf.NewReader(testcase.input), testcase.opts...)
+
+			_, err := r.ReadFrame()
+			require.ErrorIs(t, err, testcase.err)
+		})
+	}
+}
+
+func TestFrameReader_TooLargeResync(t *testing.T) {
+	buf := gbuf.NewBuffer[byte](nil)
+	w := NewFrameWriter[byte](buf)
+
+	require.NoError(t, w.WriteFrame([]byte("hello world")))
+	require.NoError(t, w.WriteFrame([]byte("ab")))
+	require.NoError(t, w.Flush())
+
+	r := NewFrameReader[byte](buf, WithMaxFrameSize[byte](4), WithSize[byte](minReadBufferSize))
+
+	_, err := r.ReadFrame()
+	require.ErrorIs(t, err, ErrFrameTooLarge)
+
+	// the oversized frame is skipped, and the next one is read in full
+	frame, err := r.ReadFrame()
+	require.NoError(t, err)
+	require.Equal(t, "ab", string(frame))
+
+	_, err = r.ReadFrame()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestFrameWriter_WriteFrame(t *testing.T) {
+	buf := gbuf.NewBuffer[byte](nil)
+
+	w := NewFrameWriter[byte](buf, WithMaxFrameSize[byte](300), WithFixedHeader[byte](1))
+	require.ErrorIs(t, w.WriteFrame(make([]byte, 256)), ErrFrameTooLarge)
+	require.ErrorIs(t, w.WriteFrame(make([]byte, 301)), ErrFrameTooLarge)
+
+	w = NewFrameWriter[byte](buf)
+	require.NoError(t, w.WriteFrame(make([]byte, 300)))
+	require.NoError(t, w.Flush())
+
+	require.Equal(t, binary.AppendUvarint(nil, 300), buf.Next(2))
+}
+
+func TestFrame_Reset(t *testing.T) {
+	first := gbuf.NewBuffer[byte](nil)
+	second := gbuf.NewBuffer[byte](nil)
+
+	w := NewFrameWriter[byte](first)
+	require.NoError(t, w.WriteFrame([]byte("discarded")))
+
+	w.Reset(second)
+	require.NoError(t, w.WriteFrame([]byte("kept")))
+	require.NoError(t, w.Flush())
+	require.Zero(t, first.Len())
+
+	r := NewFrameReader[byte](first)
+	_, err := r.ReadFrame()
+	require.ErrorIs(t, err, io.EOF)
+
+	r.Reset(second)
+
+	frame, err := r.ReadFrame()
+	require.NoError(t, err)
+	require.Equal(t, "kept", string(frame))
+}
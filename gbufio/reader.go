@@ -1,8 +1,10 @@
 package gbufio
 
 import (
+	"context"
 	"errors"
 	"io"
+	"slices"
 
 	"github.com/zalgonoise/cfg"
 	"github.com/zalgonoise/gio"
@@ -18,6 +20,7 @@ var (
 	ErrInvalidUnreadItem = errors.New("gbufio: invalid use of UnreadItem")
 	ErrBufferFull        = errors.New("gbufio: buffer full")
 	ErrNegativeCount     = errors.New("gbufio: negative count")
+	ErrInvalidDelimiter  = errors.New("gbufio: invalid delimiter sequence")
 
 	errNegativeRead = errors.New("gbufio: reader returned negative count from Read")
 )
@@ -29,6 +32,9 @@ type Reader[T any] struct {
 	r, w     int           // buf read and write positions
 	err      error
 	lastItem *T // last item read for UnreadItem; nil means invalid or unread
+
+	pending chan handoff[T] // result of an underlying Read that outlived its context
+	carry   *handoff[T]     // items from pending, not yet consumed
 }
 
 // NewReaderSize returns a new Reader whose buffer has at least the specified
@@ -86,6 +92,10 @@ func (b *Reader[T]) reset(buf []T, r gio.Reader[T]) {
 
 // fill reads a new chunk into the buffer.
 func (b *Reader[T]) fill() {
+	b.fillContext(context.Background())
+}
+
+func (b *Reader[T]) fillContext(ctx context.Context) {
 	// Slide existing data to beginning.
 	if b.r > 0 {
 		copy(b.buf, b.buf[b.r:b.w])
@@ -99,7 +109,7 @@ func (b *Reader[T]) fill() {
 
 	// Read new data: try a limited number of times.
 	for i := maxConsecutiveEmptyReads; i > 0; i-- {
-		n, err := b.rd.Read(b.buf[b.w:])
+		n, err := b.readContext(ctx, b.buf[b.w:])
 		if n < 0 {
 			panic(errNegativeRead)
 		}
@@ -129,6 +139,10 @@ func (b *Reader[T]) readErr() error {
 // Calling Peek prevents a UnreadItem call from succeeding until the next read
 // operation.
 func (b *Reader[T]) Peek(n int) ([]T, error) {
+	return b.peek(context.Background(), n)
+}
+
+func (b *Reader[T]) peek(ctx context.Context, n int) ([]T, error) {
 	if n < 0 {
 		return nil, ErrNegativeCount
 	}
@@ -136,7 +150,7 @@ func (b *Reader[T]) Peek(n int) ([]T, error) {
 	b.lastItem = nil
 
 	for b.w-b.r < n && b.w-b.r < len(b.buf) && b.err == nil {
-		b.fill() // b.w-b.r < len(b.buf) => buffer is not full
+		b.fillContext(ctx) // b.w-b.r < len(b.buf) => buffer is not full
 	}
 
 	if n > len(b.buf) {
@@ -200,6 +214,10 @@ func (b *Reader[T]) Discard(n int) (discarded int, err error) {
 // If the underlying Reader can return a non-zero count with io.EOF,
 // then this Read method can do so as well; see the [gio.Reader] docs.
 func (b *Reader[T]) Read(p []T) (n int, err error) {
+	return b.read(context.Background(), p)
+}
+
+func (b *Reader[T]) read(ctx context.Context, p []T) (n int, err error) {
 	n = len(p)
 	if n == 0 {
 		if b.Buffered() > 0 {
@@ -214,7 +232,7 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 		if len(p) >= len(b.buf) {
 			// Large read, empty buffer.
 			// Read directly into p to avoid copy.
-			n, b.err = b.rd.Read(p)
+			n, b.err = b.readContext(ctx, p)
 			if n < 0 {
 				panic(errNegativeRead)
 			}
@@ -227,7 +245,7 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 		// Do not use b.fill, which will loop.
 		b.r = 0
 		b.w = 0
-		n, b.err = b.rd.Read(b.buf)
+		n, b.err = b.readContext(ctx, b.buf)
 		if n < 0 {
 			panic(errNegativeRead)
 		}
@@ -249,11 +267,15 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 // ReadItem reads and returns a single item.
 // If no item is available, returns an error.
 func (b *Reader[T]) ReadItem() (T, error) {
+	return b.readItem(context.Background())
+}
+
+func (b *Reader[T]) readItem(ctx context.Context) (T, error) {
 	for b.r == b.w {
 		if b.err != nil {
 			return *new(T), b.readErr()
 		}
-		b.fill() // buffer is empty
+		b.fillContext(ctx) // buffer is empty
 	}
 	c := b.buf[b.r]
 	b.r++
@@ -282,6 +304,116 @@ func (b *Reader[T]) UnreadItem() error {
 	return nil
 }
 
+// ReadSliceFunc reads until the first item in the input that satisfies delim,
+// returning a slice pointing at the items in the buffer, up to and including
+// that item. It is the ReadSlice equivalent for types that do not allow
+// comparisons.
+// The items stop being valid at the next read.
+// If ReadSliceFunc encounters an error before finding a delimiter,
+// it returns all the data in the buffer and the error itself (often io.EOF).
+// ReadSliceFunc fails with error ErrBufferFull if the buffer fills without a delim.
+// ReadSliceFunc returns err != nil if and only if line does not end in a delimiter.
+func (b *Reader[T]) ReadSliceFunc(delim func(T) bool) (line []T, err error) {
+	s := 0 // search start index
+	for {
+		// Search buffer.
+		if i := slices.IndexFunc(b.buf[b.r+s:b.w], delim); i >= 0 {
+			i += s
+			line = b.buf[b.r : b.r+i+1]
+			b.r += i + 1
+			break
+		}
+
+		// Pending error?
+		if b.err != nil {
+			line = b.buf[b.r:b.w]
+			b.r = b.w
+			err = b.readErr()
+			break
+		}
+
+		// Buffer full?
+		if b.Buffered() >= len(b.buf) {
+			b.r = b.w
+			line = b.buf
+			err = ErrBufferFull
+			break
+		}
+
+		s = b.w - b.r // do not rescan area we scanned before
+
+		b.fill() // buffer is not full
+	}
+
+	// Handle last item, if any.
+	if i := len(line) - 1; i >= 0 {
+		b.lastItem = &line[i]
+	}
+
+	return
+}
+
+// ReadItemsFunc reads until the first item in the input that satisfies delim,
+// returning a slice containing the data up to and including that item. It is
+// the ReadItems equivalent for types that do not allow comparisons.
+// If ReadItemsFunc encounters an error before finding a delimiter,
+// it returns the data read before the error and the error itself (often io.EOF).
+// ReadItemsFunc returns err != nil if and only if the returned data does not end in
+// a delimiter.
+func (b *Reader[T]) ReadItemsFunc(delim func(T) bool) ([]T, error) {
+	return joinFragments(b.collectFragments(func() ([]T, error) {
+		return b.ReadSliceFunc(delim)
+	}))
+}
+
+// collectFragments reads until the first occurrence of a delimiter in the input,
+// as found by readSlice. It
+// returns (slice of full buffers, remaining bytes before delim, total number
+// of bytes in the combined first two elements, error).
+// The complete result is equal to
+// `bytes.Join(append(fullBuffers, finalFragment), nil)`, which has a
+// length of `totalLen`. The result is structured in this way to allow callers
+// to minimize allocations and copies.
+func (b *Reader[T]) collectFragments(
+	readSlice func() ([]T, error),
+) (fullBuffers [][]T, finalFragment []T, totalLen int, err error) {
+	var frag []T
+	// Use readSlice to look for delim, accumulating full buffers.
+	for {
+		var e error
+		frag, e = readSlice()
+		if e == nil { // got final fragment
+			break
+		}
+		if !errors.Is(e, ErrBufferFull) { // unexpected error
+			err = e
+			break
+		}
+
+		// Make a copy of the buffer.
+		buf := make([]T, len(frag))
+		copy(buf, frag)
+		fullBuffers = append(fullBuffers, buf)
+		totalLen += len(buf)
+	}
+
+	totalLen += len(frag)
+	return fullBuffers, frag, totalLen, err
+}
+
+// joinFragments joins the results of collectFragments into a single, newly allocated slice.
+func joinFragments[T any](full [][]T, frag []T, n int, err error) ([]T, error) {
+	// Allocate new buffer to hold the full pieces and the fragment.
+	buf := make([]T, n)
+	n = 0
+	// Copy full pieces and fragment in.
+	for i := range full {
+		n += copy(buf[n:], full[i])
+	}
+	copy(buf[n:], frag)
+	return buf, err
+}
+
 // Buffered returns the number of items that can be read from the current buffer.
 func (b *Reader[T]) Buffered() int { return b.w - b.r }
 
@@ -297,6 +429,12 @@ func (b *Reader[T]) WriteTo(w gio.Writer[T]) (n int64, err error) {
 		return
 	}
 
+	// Items from an interrupted Read must be delivered in order, so the
+	// underlying reader is only bypassed when there are none.
+	if b.pending != nil || b.carry != nil {
+		return b.writeBuffered(w, n)
+	}
+
 	if r, ok := b.rd.(gio.WriterTo[T]); ok {
 		m, err := r.WriteTo(w)
 		n += m
@@ -309,6 +447,12 @@ func (b *Reader[T]) WriteTo(w gio.Writer[T]) (n int64, err error) {
 		return n, err
 	}
 
+	return b.writeBuffered(w, n)
+}
+
+// writeBuffered writes to w by filling and draining the buffer, adding the
+// number of items written to n.
+func (b *Reader[T]) writeBuffered(w gio.Writer[T], n int64) (int64, error) {
 	if b.w-b.r < len(b.buf) {
 		b.fill() // buffer not full
 	}
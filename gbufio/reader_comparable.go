@@ -5,6 +5,7 @@ import (
 	"slices"
 
 	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
 	"github.com/zalgonoise/gio"
 )
 
@@ -12,40 +13,35 @@ type ComparableReader[T comparable] struct {
 	*Reader[T]
 
 	delim    T
+	delimSeq []T
 	excluded *T
 }
 
 // NewComparableReader returns a new Reader for types that allow comparisons, unlocking added features to work
 // with this data.
 //
-// The ComparableReader type can be configured with a set size (via WithSize) and delimiter (via WithDelim).
+// The ComparableReader type can be configured with a set size (via WithSize) and delimiter (via WithDelim, or
+// WithDelimSeq for multi-item delimiters).
 func NewComparableReader[T comparable](rd gio.Reader[T], opts ...cfg.Option[Config[T]]) *ComparableReader[T] {
 	config := cfg.Set(defaultConfig[T](), opts...)
 
-	return newComparableReader(rd, config.size, config.delim)
+	return newComparableReader(rd, config)
 }
 
-func newComparableReader[T comparable](rd gio.Reader[T], size int, delim T) *ComparableReader[T] {
+func newComparableReader[T comparable](rd gio.Reader[T], config Config[T]) *ComparableReader[T] {
 	switch r := rd.(type) {
 	case *ComparableReader[T]:
 		return r
 	case *Reader[T]:
-		return &ComparableReader[T]{Reader: r, delim: delim}
+		return &ComparableReader[T]{Reader: r, delim: config.delim, delimSeq: config.delimSeq, excluded: config.excluded}
 	}
 
-	// Is it already a Reader?
-	b, ok := rd.(*Reader[T])
-	if ok && len(b.buf) >= size {
-		return &ComparableReader[T]{Reader: b}
+	return &ComparableReader[T]{
+		Reader:   newReader(rd, config.size),
+		delim:    config.delim,
+		delimSeq: config.delimSeq,
+		excluded: config.excluded,
 	}
-
-	if size < minReadBufferSize {
-		size = minReadBufferSize
-	}
-
-	r := new(ComparableReader[T])
-	r.reset(make([]T, size), rd)
-	return r
 }
 
 // ReadSlice reads until the first occurrence of delim in the input,
@@ -115,6 +111,10 @@ func (b *ComparableReader[T]) ReadSlice(delim T) (line []T, err error) {
 // (possibly a character belonging to the line end) even if that byte is not
 // part of the line returned by ReadLine.
 func (b *ComparableReader[T]) ReadLine() (line []T, isPrefix bool, err error) {
+	if len(b.delimSeq) > 0 {
+		return b.readLineSeq()
+	}
+
 	line, err = b.ReadSlice(b.delim)
 	if errors.Is(err, ErrBufferFull) {
 		// Handle the case where the excluded item (e.g. "\r\n") straddles the buffer.
@@ -149,54 +149,109 @@ func (b *ComparableReader[T]) ReadLine() (line []T, isPrefix bool, err error) {
 	return
 }
 
-// collectFragments reads until the first occurrence of delim in the input. It
-// returns (slice of full buffers, remaining bytes before delim, total number
-// of bytes in the combined first two elements, error).
-// The complete result is equal to
-// `bytes.Join(append(fullBuffers, finalFragment), nil)`, which has a
-// length of `totalLen`. The result is structured in this way to allow callers
-// to minimize allocations and copies.
-func (b *ComparableReader[T]) collectFragments(delim T) (fullBuffers [][]T, finalFragment []T, totalLen int, err error) {
-	var frag []T
-	// Use ReadSlice to look for delim, accumulating full buffers.
+// ReadItems reads until the first occurrence of delim in the input,
+// returning a slice containing the data up to and including the delimiter.
+// If ReadBytes encounters an error before finding a delimiter,
+// it returns the data read before the error and the error itself (often io.EOF).
+// ReadBytes returns err != nil if and only if the returned data does not end in
+// delim.
+// For simple uses, a Scanner may be more convenient.
+func (b *ComparableReader[T]) ReadItems(delim T) ([]T, error) {
+	return joinFragments(b.collectFragments(func() ([]T, error) {
+		return b.ReadSlice(delim)
+	}))
+}
+
+// ReadSliceSeq is like ReadSlice, but reads until the first occurrence of the multi-item
+// delimiter delim in the input, which may straddle several fills of the buffer.
+// The returned slice points at the items in the buffer, and stops being valid at the
+// next read.
+//
+// If the buffer fills without a delimiter, ReadSliceSeq returns ErrBufferFull with all but
+// the last len(delim)-1 items in the buffer, keeping those as they may be the start of
+// a delimiter. ReadSliceSeq returns ErrInvalidDelimiter if delim is empty, or if it does
+// not fit in the buffer.
+// ReadSliceSeq returns err != nil if and only if line does not end in delim.
+func (b *ComparableReader[T]) ReadSliceSeq(delim []T) (line []T, err error) {
+	if len(delim) == 0 || len(delim) >= len(b.buf) {
+		return nil, ErrInvalidDelimiter
+	}
+
+	s := 0 // search start index
 	for {
-		var e error
-		frag, e = b.ReadSlice(delim)
-		if e == nil { // got final fragment
+		// Search buffer.
+		if i := gbuf.Index(b.buf[b.r+s:b.w], delim); i >= 0 {
+			i += s
+			line = b.buf[b.r : b.r+i+len(delim)]
+			b.r += i + len(delim)
 			break
 		}
-		if !errors.Is(e, ErrBufferFull) { // unexpected error
-			err = e
+
+		// Pending error?
+		if b.err != nil {
+			line = b.buf[b.r:b.w]
+			b.r = b.w
+			err = b.readErr()
 			break
 		}
 
-		// Make a copy of the buffer.
-		buf := make([]T, len(frag))
-		copy(buf, frag)
-		fullBuffers = append(fullBuffers, buf)
-		totalLen += len(buf)
+		// Buffer full? Keep any partial delimiter in the buffer.
+		if b.Buffered() >= len(b.buf) {
+			n := b.w - len(delim) + 1
+			line = b.buf[b.r:n]
+			b.r = n
+			err = ErrBufferFull
+			break
+		}
+
+		// do not rescan area we scanned before, except for a partial delimiter
+		s = max(b.w-b.r-len(delim)+1, 0)
+
+		b.fill() // buffer is not full
+	}
+
+	// Handle last item, if any.
+	if i := len(line) - 1; i >= 0 {
+		b.lastItem = &line[i]
 	}
 
-	totalLen += len(frag)
-	return fullBuffers, frag, totalLen, err
+	return
 }
 
-// ReadItems reads until the first occurrence of delim in the input,
-// returning a slice containing the data up to and including the delimiter.
-// If ReadBytes encounters an error before finding a delimiter,
+// ReadItemsSeq is like ReadItems, but reads until the first occurrence of the multi-item
+// delimiter delim in the input, returning a slice containing the data up to and including
+// the delimiter.
+// If ReadItemsSeq encounters an error before finding a delimiter,
 // it returns the data read before the error and the error itself (often io.EOF).
-// ReadBytes returns err != nil if and only if the returned data does not end in
+// ReadItemsSeq returns err != nil if and only if the returned data does not end in
 // delim.
-// For simple uses, a Scanner may be more convenient.
-func (b *ComparableReader[T]) ReadItems(delim T) ([]T, error) {
-	full, frag, n, err := b.collectFragments(delim)
-	// Allocate new buffer to hold the full pieces and the fragment.
-	buf := make([]T, n)
-	n = 0
-	// Copy full pieces and fragment in.
-	for i := range full {
-		n += copy(buf[n:], full[i])
+func (b *ComparableReader[T]) ReadItemsSeq(delim []T) ([]T, error) {
+	if len(delim) == 0 || len(delim) >= len(b.buf) {
+		return nil, ErrInvalidDelimiter
+	}
+
+	return joinFragments(b.collectFragments(func() ([]T, error) {
+		return b.ReadSliceSeq(delim)
+	}))
+}
+
+// readLineSeq is the ReadLine implementation for a multi-item delimiter, set with WithDelimSeq.
+func (b *ComparableReader[T]) readLineSeq() (line []T, isPrefix bool, err error) {
+	line, err = b.ReadSliceSeq(b.delimSeq)
+	if errors.Is(err, ErrBufferFull) {
+		return line, true, nil
 	}
-	copy(buf[n:], frag)
-	return buf, err
+
+	if len(line) == 0 {
+		if err != nil {
+			line = nil
+		}
+		return
+	}
+
+	if err == nil {
+		line = line[:len(line)-len(b.delimSeq)]
+	}
+
+	return line, false, nil
 }
@@ -0,0 +1,158 @@
+package gbufio
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gio"
+)
+
+// oneItemReader returns a single item on each Read call.
+type oneItemReader[T any] struct {
+	r gio.Reader[T]
+}
+
+func (r oneItemReader[T]) Read(p []T) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	return r.r.Read(p[:1])
+}
+
+func TestComparableReader_ReadItemsSeq(t *testing.T) {
+	crlf := []byte("\r\n")
+	long := strings.Repeat("x", 3*minReadBufferSize+1)
+
+	for _, testcase := range []struct {
+		name  string
+		input string
+		wants []string
+		err   error
+	}{
+		{
+			name:  "Simple",
+			input: "one\r\ntwo\r\n",
+			wants: []string{"one\r\n", "two\r\n"},
+			err:   io.EOF,
+		},
+		{
+			name:  "LoneItems",
+			input: "a\rb\nc\r\n\r\r\n",
+			wants: []string{"a\rb\nc\r\n", "\r\r\n"},
+			err:   io.EOF,
+		},
+		{
+			name:  "LongerThanBuffer",
+			input: long + "\r\n" + long,
+			wants: []string{long + "\r\n", long},
+			err:   io.EOF,
+		},
+		{
+			// the delimiter straddles the buffer's fill boundary
+			name:  "Straddling",
+			input: strings.Repeat("y", minReadBufferSize-1) + "\r\nz",
+			wants: []string{strings.Repeat("y", minReadBufferSize-1) + "\r\n", "z"},
+			err:   io.EOF,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := NewComparableReader[byte](
+				oneItemReader[byte]{gbuf.NewReader([]byte(testcase.input))},
+				WithSize[byte](minReadBufferSize),
+			)
+
+			var err error
+
+			for _, wants := range testcase.wants {
+				var line []byte
+
+				line, err = r.ReadItemsSeq(crlf)
+				require.Equal(t, wants, string(line))
+			}
+
+			_, err = r.ReadItemsSeq(crlf)
+			require.ErrorIs(t, err, testcase.err)
+		})
+	}
+
+	_, err := NewComparableReader[byte](gbuf.NewReader([]byte("a"))).ReadItemsSeq(nil)
+	require.ErrorIs(t, err, ErrInvalidDelimiter)
+}
+
+func TestComparableReader_ReadLine_DelimSeq(t *testing.T) {
+	r := NewComparableReader[byte](
+		oneItemReader[byte]{gbuf.NewReader([]byte("GET / HTTP/1.1\r\nHost: a\nb\r\n\r\nbody"))},
+		WithSize[byte](minReadBufferSize), WithDelimSeq[byte]('\r', '\n'),
+	)
+
+	var lines []string
+
+	for {
+		line, isPrefix, err := r.ReadLine()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+
+			break
+		}
+
+		require.False(t, isPrefix)
+
+		lines = append(lines, string(line))
+	}
+
+	require.Equal(t, []string{"GET / HTTP/1.1", "Host: a\nb", "", "body"}, lines)
+}
+
+func TestScanner_DelimSeq(t *testing.T) {
+	s := NewScanner[byte](
+		oneItemReader[byte]{gbuf.NewReader([]byte("a::b:c::::d"))},
+		WithDelimSeq[byte](':', ':'),
+	)
+
+	var tokens []string
+
+	for s.Scan() {
+		tokens = append(tokens, string(s.Value()))
+	}
+
+	require.NoError(t, s.Err())
+	require.Equal(t, []string{"a", "b:c", "", "d"}, tokens)
+}
+
+func TestNewComparableReader(t *testing.T) {
+	excluded := byte('\r')
+
+	t.Run("Reader", func(t *testing.T) {
+		r := NewComparableReader[byte](gbuf.NewReader([]byte("one\r\ntwo")),
+			WithDelim[byte]('\n'), WithExcluded(&excluded),
+		)
+
+		line, isPrefix, err := r.ReadLine()
+		require.NoError(t, err)
+		require.False(t, isPrefix)
+		require.Equal(t, "one", string(line))
+	})
+
+	t.Run("BufferedReader", func(t *testing.T) {
+		// an existing Reader is wrapped as is, keeping its buffered items
+		b := NewReader[byte](gbuf.NewReader([]byte("ab\ncd")), WithSize[byte](minReadBufferSize))
+		_, err := b.Peek(1)
+		require.NoError(t, err)
+
+		r := NewComparableReader[byte](b, WithDelim[byte]('\n'))
+		require.Same(t, b, r.Reader)
+
+		line, err := r.ReadItems(r.delim)
+		require.NoError(t, err)
+		require.Equal(t, "ab\n", string(line))
+	})
+
+	t.Run("ComparableReader", func(t *testing.T) {
+		r := NewComparableReader[byte](gbuf.NewReader([]byte("ab")))
+		require.Same(t, r, NewComparableReader[byte](r, WithDelim[byte]('\n')))
+	})
+}
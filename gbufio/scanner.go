@@ -3,9 +3,11 @@ package gbufio
 import (
 	"errors"
 	"io"
+	"iter"
 	"slices"
 
 	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
 	"github.com/zalgonoise/gio"
 )
 
@@ -25,20 +27,32 @@ import (
 // control over error handling or large tokens, or must run sequential scans
 // on a reader, should use bufio.Reader instead.
 type Scanner[T comparable] struct {
-	r     gio.Reader[T] // The reader provided by the client.
-	split SplitFunc[T]  // The function to split the tokens.
-
-	delim        T     // Delimiter to use when splitting reads
-	excluded     *T    // Excluded value to remove, usually next to the delimiter. Ignored if nil.
-	maxTokenSize int   // Maximum size of a token; modified by tests.
-	token        []T   // Last token returned by split.
-	buf          []T   // Buffer used as argument to split.
-	start        int   // First non-processed byte in buf.
-	end          int   // End of data in buf.
-	err          error // Sticky error.
-	empties      int   // Count of successive empty tokens.
-	scanCalled   bool  // Scan has been called; buffer is in use.
-	done         bool  // Scan has finished.
+	scanner[T]
+
+	split    SplitFunc[T] // The function to split the tokens.
+	delim    T            // Delimiter to use when splitting reads
+	excluded *T           // Excluded value to remove, usually next to the delimiter. Ignored if nil.
+}
+
+// splitter tokenizes the data in a scanner's buffer, with its split function
+// and delimiter.
+type splitter[T any] interface {
+	splitData(data []T, atEOF bool) (advance int, token []T, err error)
+}
+
+// scanner holds the state of a Scanner or ScannerFunc, which only differ in
+// how their split functions and delimiters are defined.
+type scanner[T any] struct {
+	r            gio.Reader[T] // The reader provided by the client.
+	maxTokenSize int           // Maximum size of a token; modified by tests.
+	token        []T           // Last token returned by split.
+	buf          []T           // Buffer used as argument to split.
+	start        int           // First non-processed byte in buf.
+	end          int           // End of data in buf.
+	err          error         // Sticky error.
+	empties      int           // Count of successive empty tokens.
+	scanCalled   bool          // Scan has been called; buffer is in use.
+	done         bool          // Scan has finished.
 }
 
 // SplitFunc is the signature of the split function used to tokenize the
@@ -84,21 +98,29 @@ const (
 )
 
 // NewScanner returns a new Scanner to read from r.
-// The split function defaults to ScanLines.
+// The split function defaults to ScanLines, or to splitting on the multi-item
+// delimiter set with WithDelimSeq, if any.
 func NewScanner[T comparable](r gio.Reader[T], opts ...cfg.Option[Config[T]]) *Scanner[T] {
 	config := cfg.New(opts...)
 
+	split := ScanLines[T]
+	if len(config.delimSeq) > 0 {
+		split = ScanSeq(config.delimSeq)
+	}
+
 	return &Scanner[T]{
-		r:            r,
-		split:        ScanLines[T],
-		delim:        config.delim,
-		excluded:     config.excluded,
-		maxTokenSize: MaxScanTokenSize,
+		scanner: scanner[T]{
+			r:            r,
+			maxTokenSize: MaxScanTokenSize,
+		},
+		split:    split,
+		delim:    config.delim,
+		excluded: config.excluded,
 	}
 }
 
 // Err returns the first non-EOF error that was encountered by the Scanner.
-func (s *Scanner[T]) Err() error {
+func (s *scanner[T]) Err() error {
 	if s.err == io.EOF {
 		return nil
 	}
@@ -108,7 +130,7 @@ func (s *Scanner[T]) Err() error {
 // Value returns the most recent token generated by a call to Scan.
 // The underlying array may point to data that will be overwritten
 // by a subsequent call to Scan. It does no allocation.
-func (s *Scanner[T]) Value() []T {
+func (s *scanner[T]) Value() []T {
 	return s.token
 }
 
@@ -132,6 +154,56 @@ var ErrFinalToken = errors.New("final token")
 // tokens without advancing the input. This is a common error mode for
 // scanners.
 func (s *Scanner[T]) Scan() bool {
+	return s.scan(s)
+}
+
+// All returns an iterator over the remaining tokens, as generated by successive
+// calls to Scan. Each token is only valid until the next iteration. Once the
+// iteration is done, the Err method returns any error that occurred during
+// scanning.
+func (s *Scanner[T]) All() iter.Seq[[]T] {
+	return s.all(s)
+}
+
+// Tokens returns an iterator over the remaining tokens, as generated by
+// successive calls to Scan, paired with a nil error. Each token is only valid
+// until the next iteration. If scanning stops with an error other than io.EOF,
+// it is yielded last, with a nil token.
+func (s *Scanner[T]) Tokens() iter.Seq2[[]T, error] {
+	return s.tokens(s)
+}
+
+func (s *Scanner[T]) splitData(data []T, atEOF bool) (advance int, token []T, err error) {
+	return s.split(data, s.delim, s.excluded, atEOF)
+}
+
+func (s *scanner[T]) all(sp splitter[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for s.scan(sp) {
+			if !yield(s.token) {
+				return
+			}
+		}
+	}
+}
+
+func (s *scanner[T]) tokens(sp splitter[T]) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		for s.scan(sp) {
+			if !yield(s.token, nil) {
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// scan advances the scanner to the next token, as described in Scanner.Scan,
+// splitting the data in the buffer with sp.
+func (s *scanner[T]) scan(sp splitter[T]) bool {
 	if s.done {
 		return false
 	}
@@ -142,7 +214,7 @@ func (s *Scanner[T]) Scan() bool {
 		// If we've run out of data but have an error, give the split function
 		// a chance to recover any remaining, possibly empty token.
 		if s.end > s.start || s.err != nil {
-			advance, token, err := s.split(s.buf[s.start:s.end], s.delim, s.excluded, s.err != nil)
+			advance, token, err := sp.splitData(s.buf[s.start:s.end], s.err != nil)
 			if err != nil {
 				if errors.Is(err, ErrFinalToken) {
 					s.token = token
@@ -234,7 +306,7 @@ func (s *Scanner[T]) Scan() bool {
 }
 
 // advance consumes n bytes of the buffer. It reports whether the advance was legal.
-func (s *Scanner[T]) advance(n int) bool {
+func (s *scanner[T]) advance(n int) bool {
 	if n < 0 {
 		s.setErr(ErrNegativeAdvance)
 		return false
@@ -248,7 +320,7 @@ func (s *Scanner[T]) advance(n int) bool {
 }
 
 // setErr records the first error encountered.
-func (s *Scanner[T]) setErr(err error) {
+func (s *scanner[T]) setErr(err error) {
 	if s.err == nil || s.err == io.EOF {
 		s.err = err
 	}
@@ -263,7 +335,7 @@ func (s *Scanner[T]) setErr(err error) {
 // maximum token size to MaxScanTokenSize.
 //
 // Buffer panics if it is called after scanning has started.
-func (s *Scanner[T]) Buffer(buf []T, max int) {
+func (s *scanner[T]) Buffer(buf []T, max int) {
 	if s.scanCalled {
 		panic("Buffer called after Scan")
 	}
@@ -286,7 +358,7 @@ func (s *Scanner[T]) Split(split SplitFunc[T]) {
 
 // dropExcluded drops a specific excluded value from the data, if set.
 func dropExcluded[T comparable](data []T, zero *T) []T {
-	if len(data) > 0 && *zero != nil && data[len(data)-1] == *zero {
+	if len(data) > 0 && zero != nil && data[len(data)-1] == *zero {
 		return data[0 : len(data)-1]
 	}
 	return data
@@ -313,3 +385,101 @@ func ScanLines[T comparable](data []T, delim T, excluded *T, atEOF bool) (advanc
 	// Request more data.
 	return 0, nil, nil
 }
+
+// ScanItems is a split function for a Scanner that returns each item as a token.
+func ScanItems[T comparable](data []T, _ T, _ *T, atEOF bool) (advance int, token []T, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+	return 1, data[0:1], nil
+}
+
+// ScanFixed returns a split function for a Scanner that returns fixed-size
+// records of n items as tokens. If the input ends with an incomplete record,
+// scanning stops with io.ErrUnexpectedEOF. ScanFixed panics if n is not positive.
+func ScanFixed[T comparable](n int) SplitFunc[T] {
+	if n <= 0 {
+		panic("gbufio: non-positive ScanFixed record size")
+	}
+
+	return func(data []T, _ T, _ *T, atEOF bool) (advance int, token []T, err error) {
+		if len(data) >= n {
+			return n, data[0:n], nil
+		}
+		if atEOF {
+			if len(data) == 0 {
+				return 0, nil, nil
+			}
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+		// Request more data.
+		return 0, nil, nil
+	}
+}
+
+// ScanRuns is a split function for a Scanner that returns each run of equal,
+// consecutive items as a token.
+func ScanRuns[T comparable](data []T, _ T, _ *T, atEOF bool) (advance int, token []T, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+	for i := 1; i < len(data); i++ {
+		if data[i] != data[0] {
+			return i, data[0:i], nil
+		}
+	}
+	// If we're at EOF, we have a final run. Return it.
+	if atEOF {
+		return len(data), data, nil
+	}
+	// Request more data.
+	return 0, nil, nil
+}
+
+// ScanFields is a split function for a Scanner that returns each sequence of
+// items separated by one or more delimiter items, with the delimiters removed.
+// It never returns an empty token.
+func ScanFields[T comparable](data []T, delim T, _ *T, atEOF bool) (advance int, token []T, err error) {
+	// Skip leading delimiters.
+	start := 0
+	for start < len(data) && data[start] == delim {
+		start++
+	}
+	// Scan until a delimiter, marking the end of the field.
+	if i := slices.Index(data[start:], delim); i >= 0 {
+		return start + i + 1, data[start : start+i], nil
+	}
+	// If we're at EOF, we have a final, non-empty, non-terminated field. Return it.
+	if atEOF && len(data) > start {
+		return len(data), data[start:], nil
+	}
+	// Request more data, dropping any leading delimiters.
+	return start, nil, nil
+}
+
+// ScanSeq returns a split function for a Scanner that returns each line of
+// data, stripped of the trailing multi-item delimiter delim, such as a CRLF
+// sequence. The returned line may be empty. The last non-empty line of input
+// will be returned even if it has no delimiter. ScanSeq panics if delim is
+// empty.
+func ScanSeq[T comparable](delim []T) SplitFunc[T] {
+	if len(delim) == 0 {
+		panic("gbufio: empty ScanSeq delimiter")
+	}
+
+	return func(data []T, _ T, _ *T, atEOF bool) (advance int, token []T, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		if i := gbuf.Index(data, delim); i >= 0 {
+			// We have a full delimiter-terminated line.
+			return i + len(delim), data[0:i], nil
+		}
+		// If we're at EOF, we have a final, non-terminated line. Return it.
+		if atEOF {
+			return len(data), data, nil
+		}
+		// Request more data.
+		return 0, nil, nil
+	}
+}
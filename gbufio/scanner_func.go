@@ -0,0 +1,109 @@
+package gbufio
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gio"
+)
+
+// ScannerFunc is a Scanner for types that do not allow comparisons, such as
+// structs containing slices or maps. Instead of a delimiter item, its split
+// functions take a delimiter predicate, of type SplitPredicateFunc.
+//
+// Its behavior is otherwise the same as a Scanner's.
+type ScannerFunc[T any] struct {
+	scanner[T]
+
+	split SplitPredicateFunc[T] // The function to split the tokens.
+	delim func(T) bool          // Delimiter predicate to use when splitting reads
+}
+
+// SplitPredicateFunc is the signature of the split function used to tokenize the
+// input of a ScannerFunc. It is the same as a SplitFunc, except that delimiters
+// are identified by the predicate delim, rather than by comparison.
+type SplitPredicateFunc[T any] func(data []T, delim func(T) bool, atEOF bool) (advance int, token []T, err error)
+
+// NewScannerFunc returns a new ScannerFunc to read from r.
+// The split function defaults to ScanLinesFunc, with the delimiter predicate set
+// with WithDelimFunc. Without a delimiter predicate, the entire input is a single
+// token.
+func NewScannerFunc[T any](r gio.Reader[T], opts ...cfg.Option[Config[T]]) *ScannerFunc[T] {
+	config := cfg.New(opts...)
+
+	delim := config.delimFunc
+	if delim == nil {
+		delim = func(T) bool { return false }
+	}
+
+	return &ScannerFunc[T]{
+		scanner: scanner[T]{
+			r:            r,
+			maxTokenSize: MaxScanTokenSize,
+		},
+		split: ScanLinesFunc[T],
+		delim: delim,
+	}
+}
+
+// Scan advances the ScannerFunc to the next token, which will then be
+// available through the Value method. It returns false when the
+// scan stops, either by reaching the end of the input or an error.
+// After Scan returns false, the Err method will return any error that
+// occurred during scanning, except that if it was io.EOF, Err
+// will return nil.
+func (s *ScannerFunc[T]) Scan() bool {
+	return s.scan(s)
+}
+
+// All returns an iterator over the remaining tokens, as generated by successive
+// calls to Scan. Each token is only valid until the next iteration. Once the
+// iteration is done, the Err method returns any error that occurred during
+// scanning.
+func (s *ScannerFunc[T]) All() iter.Seq[[]T] {
+	return s.all(s)
+}
+
+// Tokens returns an iterator over the remaining tokens, as generated by
+// successive calls to Scan, paired with a nil error. Each token is only valid
+// until the next iteration. If scanning stops with an error other than io.EOF,
+// it is yielded last, with a nil token.
+func (s *ScannerFunc[T]) Tokens() iter.Seq2[[]T, error] {
+	return s.tokens(s)
+}
+
+func (s *ScannerFunc[T]) splitData(data []T, atEOF bool) (advance int, token []T, err error) {
+	return s.split(data, s.delim, atEOF)
+}
+
+// Split sets the split function for the ScannerFunc.
+// The default split function is ScanLinesFunc.
+//
+// Split panics if it is called after scanning has started.
+func (s *ScannerFunc[T]) Split(split SplitPredicateFunc[T]) {
+	if s.scanCalled {
+		panic("Split called after Scan")
+	}
+	s.split = split
+}
+
+// ScanLinesFunc is a split function for a ScannerFunc that returns each line
+// of data, stripped of the trailing item that satisfies delim. The returned
+// line may be empty. The last non-empty line of input will be returned even
+// if it has no delimiter.
+func ScanLinesFunc[T any](data []T, delim func(T) bool, atEOF bool) (advance int, token []T, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := slices.IndexFunc(data, delim); i >= 0 {
+		// We have a full delimiter-terminated line.
+		return i + 1, data[0:i], nil
+	}
+	// If we're at EOF, we have a final, non-terminated line. Return it.
+	if atEOF {
+		return len(data), data, nil
+	}
+	// Request more data.
+	return 0, nil, nil
+}
@@ -0,0 +1,92 @@
+package gbufio
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gbuf"
+)
+
+// event is not comparable, as it contains a map.
+type event struct {
+	kind  string
+	attrs map[string]string
+}
+
+func isEnd(e event) bool { return e.kind == "end" }
+
+func kinds(events []event) []string {
+	k := make([]string, 0, len(events))
+
+	for i := range events {
+		k = append(k, events[i].kind)
+	}
+
+	return k
+}
+
+func TestReader_ReadItemsFunc(t *testing.T) {
+	input := []event{{kind: "a"}, {kind: "end"}, {kind: "b", attrs: map[string]string{"k": "v"}}, {kind: "c"}}
+	r := NewReader[event](oneItemReader[event]{gbuf.NewReader(input)}, WithSize[event](minReadBufferSize))
+
+	items, err := r.ReadItemsFunc(isEnd)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "end"}, kinds(items))
+
+	items, err = r.ReadItemsFunc(isEnd)
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []string{"b", "c"}, kinds(items))
+}
+
+func TestScannerFunc(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input []event
+		wants [][]string
+	}{
+		{
+			name:  "Empty",
+			input: nil,
+			wants: nil,
+		},
+		{
+			name:  "Terminated",
+			input: []event{{kind: "a"}, {kind: "b"}, {kind: "end"}, {kind: "end"}, {kind: "c"}, {kind: "end"}},
+			wants: [][]string{{"a", "b"}, {}, {"c"}},
+		},
+		{
+			name:  "Unterminated",
+			input: []event{{kind: "a"}, {kind: "end"}, {kind: "b"}},
+			wants: [][]string{{"a"}, {"b"}},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			s := NewScannerFunc[event](oneItemReader[event]{gbuf.NewReader(testcase.input)}, WithDelimFunc(isEnd))
+
+			var tokens [][]string
+
+			for s.Scan() {
+				tokens = append(tokens, kinds(s.Value()))
+			}
+
+			require.NoError(t, s.Err())
+			require.Equal(t, testcase.wants, tokens)
+		})
+	}
+}
+
+func TestScannerFunc_Tokens(t *testing.T) {
+	input := []event{{kind: "a"}, {kind: "end"}, {kind: "b"}}
+	s := NewScannerFunc[event](gbuf.NewReader(input), WithDelimFunc(isEnd))
+
+	var tokens [][]string
+
+	for token, err := range s.Tokens() {
+		require.NoError(t, err)
+
+		tokens = append(tokens, kinds(token))
+	}
+
+	require.Equal(t, [][]string{{"a"}, {"b"}}, tokens)
+}
@@ -0,0 +1,216 @@
+package gbufio
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gio"
+)
+
+type splitResult struct {
+	advance int
+	token   []byte
+	err     error
+}
+
+func TestSplitFuncs(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		split SplitFunc[byte]
+		data  []byte
+		atEOF bool
+		wants splitResult
+	}{
+		{name: "ScanItems/Empty", split: ScanItems[byte], data: nil},
+		{name: "ScanItems/EmptyAtEOF", split: ScanItems[byte], data: nil, atEOF: true},
+		{name: "ScanItems/Single", split: ScanItems[byte], data: []byte("abc"), wants: splitResult{1, []byte("a"), nil}},
+		{name: "ScanItems/SingleAtEOF", split: ScanItems[byte], data: []byte("c"), atEOF: true, wants: splitResult{1, []byte("c"), nil}},
+
+		{name: "ScanFixed/Full", split: ScanFixed[byte](2), data: []byte("abc"), wants: splitResult{2, []byte("ab"), nil}},
+		{name: "ScanFixed/Short", split: ScanFixed[byte](2), data: []byte("a")},
+		{name: "ScanFixed/ShortAtEOF", split: ScanFixed[byte](2), data: []byte("a"), atEOF: true, wants: splitResult{err: io.ErrUnexpectedEOF}},
+		{name: "ScanFixed/EmptyAtEOF", split: ScanFixed[byte](2), data: nil, atEOF: true},
+		{name: "ScanFixed/ExactAtEOF", split: ScanFixed[byte](2), data: []byte("ab"), atEOF: true, wants: splitResult{2, []byte("ab"), nil}},
+
+		{name: "ScanRuns/Empty", split: ScanRuns[byte], data: nil},
+		{name: "ScanRuns/EmptyAtEOF", split: ScanRuns[byte], data: nil, atEOF: true},
+		{name: "ScanRuns/Run", split: ScanRuns[byte], data: []byte("aaab"), wants: splitResult{3, []byte("aaa"), nil}},
+		{name: "ScanRuns/Open", split: ScanRuns[byte], data: []byte("aaa")},
+		{name: "ScanRuns/OpenAtEOF", split: ScanRuns[byte], data: []byte("aaa"), atEOF: true, wants: splitResult{3, []byte("aaa"), nil}},
+
+		{name: "ScanFields/Field", split: ScanFields[byte], data: []byte("ab cd"), wants: splitResult{3, []byte("ab"), nil}},
+		{name: "ScanFields/LeadingDelims", split: ScanFields[byte], data: []byte("   ab "), wants: splitResult{6, []byte("ab"), nil}},
+		{name: "ScanFields/OnlyDelims", split: ScanFields[byte], data: []byte("   "), wants: splitResult{advance: 3}},
+		{name: "ScanFields/OnlyDelimsAtEOF", split: ScanFields[byte], data: []byte("   "), atEOF: true, wants: splitResult{advance: 3}},
+		{name: "ScanFields/Open", split: ScanFields[byte], data: []byte(" ab"), wants: splitResult{advance: 1}},
+		{name: "ScanFields/OpenAtEOF", split: ScanFields[byte], data: []byte(" ab"), atEOF: true, wants: splitResult{3, []byte("ab"), nil}},
+		{name: "ScanFields/EmptyAtEOF", split: ScanFields[byte], data: nil, atEOF: true},
+
+		{name: "ScanSeq/Line", split: ScanSeq([]byte("\r\n")), data: []byte("ab\r\ncd"), wants: splitResult{4, []byte("ab"), nil}},
+		{name: "ScanSeq/EmptyLine", split: ScanSeq([]byte("\r\n")), data: []byte("\r\n"), wants: splitResult{2, []byte{}, nil}},
+		{name: "ScanSeq/PartialDelim", split: ScanSeq([]byte("\r\n")), data: []byte("ab\r")},
+		{name: "ScanSeq/PartialDelimAtEOF", split: ScanSeq([]byte("\r\n")), data: []byte("ab\r"), atEOF: true, wants: splitResult{3, []byte("ab\r"), nil}},
+		{name: "ScanSeq/EmptyAtEOF", split: ScanSeq([]byte("\r\n")), data: nil, atEOF: true},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			advance, token, err := testcase.split(testcase.data, ' ', nil, testcase.atEOF)
+
+			require.ErrorIs(t, err, testcase.wants.err)
+			require.Equal(t, testcase.wants.advance, advance)
+			require.Equal(t, testcase.wants.token, token)
+		})
+	}
+}
+
+func TestScanner_SplitFuncs(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		split SplitFunc[byte]
+		input string
+		wants []string
+		err   error
+	}{
+		{
+			name:  "ScanItems",
+			split: ScanItems[byte],
+			input: "abc",
+			wants: []string{"a", "b", "c"},
+		},
+		{
+			name:  "ScanFixed",
+			split: ScanFixed[byte](3),
+			input: "abcdefghi",
+			wants: []string{"abc", "def", "ghi"},
+		},
+		{
+			name:  "ScanFixed/Truncated",
+			split: ScanFixed[byte](3),
+			input: "abcdefgh",
+			wants: []string{"abc", "def"},
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "ScanRuns",
+			split: ScanRuns[byte],
+			input: "aaabccdddd",
+			wants: []string{"aaa", "b", "cc", "dddd"},
+		},
+		{
+			name:  "ScanFields",
+			split: ScanFields[byte],
+			input: "\n\nfoo\nbar\n\n\nbaz\n",
+			wants: []string{"foo", "bar", "baz"},
+		},
+		{
+			name:  "ScanSeq",
+			split: ScanSeq([]byte("\r\n")),
+			input: "foo\r\n\r\nbar\rbaz\r\nqux",
+			wants: []string{"foo", "", "bar\rbaz", "qux"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			s := NewScanner[byte](
+				oneItemReader[byte]{gbuf.NewReader([]byte(testcase.input))},
+				WithSize[byte](minReadBufferSize),
+				WithDelim[byte]('\n'),
+			)
+			s.Split(testcase.split)
+
+			var tokens []string
+			for s.Scan() {
+				tokens = append(tokens, string(s.Value()))
+			}
+
+			require.ErrorIs(t, s.Err(), testcase.err)
+			require.Equal(t, testcase.wants, tokens)
+		})
+	}
+}
+
+// errAfterReader returns err once the wrapped reader is drained.
+type errAfterReader struct {
+	r   gio.Reader[byte]
+	err error
+}
+
+func (r errAfterReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, r.err
+	}
+
+	return n, err
+}
+
+func TestScanner_Tokens(t *testing.T) {
+	errBroken := errors.New("broken stream")
+
+	for _, testcase := range []struct {
+		name  string
+		r     gio.Reader[byte]
+		wants []string
+		err   error
+	}{
+		{
+			name:  "EOF",
+			r:     gbuf.NewReader([]byte("a\nb\nc")),
+			wants: []string{"a", "b", "c"},
+		},
+		{
+			name:  "Error",
+			r:     errAfterReader{r: gbuf.NewReader([]byte("a\nb\n")), err: errBroken},
+			wants: []string{"a", "b"},
+			err:   errBroken,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			s := NewScanner[byte](testcase.r, WithDelim[byte]('\n'))
+
+			var (
+				tokens []string
+				err    error
+			)
+
+			for token, e := range s.Tokens() {
+				if e != nil {
+					err = e
+
+					continue
+				}
+
+				tokens = append(tokens, string(token))
+			}
+
+			require.ErrorIs(t, err, testcase.err)
+			require.Equal(t, testcase.wants, tokens)
+		})
+	}
+}
+
+func TestScanner_All(t *testing.T) {
+	s := NewScanner[byte](gbuf.NewReader([]byte("a b  c")), WithDelim[byte](' '))
+	s.Split(ScanFields[byte])
+
+	var tokens []string
+
+	for token := range s.All() {
+		tokens = append(tokens, string(token))
+
+		if len(tokens) == 2 {
+			break
+		}
+	}
+
+	require.Equal(t, []string{"a", "b"}, tokens)
+
+	// iteration resumes from where it stopped
+	for token := range s.All() {
+		tokens = append(tokens, string(token))
+	}
+
+	require.Equal(t, []string{"a", "b", "c"}, tokens)
+	require.NoError(t, s.Err())
+}
@@ -1,10 +1,14 @@
 package gbufio
 
 import (
+	"context"
 	"errors"
 	"io"
+	"slices"
+	"sync"
 
 	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/gbuf"
 	"github.com/zalgonoise/gio"
 )
 
@@ -16,11 +20,28 @@ var errNegativeWrite = errors.New("gbufio: writer returned negative count from W
 // After all data has been written, the client should call the
 // Flush method to guarantee all data has been forwarded to
 // the underlying io.Writer.
+//
+// A Writer configured with WithFlushInterval also flushes its buffered data
+// periodically, from a background goroutine, and must be closed with Close.
+// Such a Writer is also safe for concurrent use; other Writers are not.
+// A Writer configured with WithFlushOn flushes its buffered data whenever the
+// delimiter item is written.
 type Writer[T any] struct {
+	// mu is only set for Writers configured with WithFlushInterval, guarding
+	// the buffer against the background flushes.
+	mu  *sync.Mutex
 	err error
 	buf []T
 	n   int
 	wr  gio.Writer[T]
+
+	// inflight is set while a write abandoned by a done context is still
+	// running, and closed once it returns.
+	inflight chan struct{}
+
+	flushOn func(T) bool
+	done    chan struct{}
+	stopped chan struct{}
 }
 
 // NewWriterSize returns a new Writer whose buffer has at least the specified
@@ -43,18 +64,99 @@ func newWriter[T any](w gio.Writer[T], size int) *Writer[T] {
 
 // NewWriter returns a new Writer whose buffer has the default size.
 // If the argument gio.Writer is already a Writer with large enough buffer size,
-// it returns the underlying Writer.
+// and no automatic flushing is configured, it returns the underlying Writer.
+//
+// The Writer can be configured to flush periodically (via WithFlushInterval,
+// and optionally WithClock) or on a delimiter item (via WithFlushOn).
 func NewWriter[T any](w gio.Writer[T], opts ...cfg.Option[Config[T]]) *Writer[T] {
 	config := cfg.Set(defaultConfig[T](), opts...)
 
-	return newWriter(w, config.size)
+	if config.flushInterval <= 0 && config.flushOn == nil {
+		return newWriter(w, config.size)
+	}
+
+	size := config.size
+	if size <= 0 {
+		size = defaultBufSize
+	}
+
+	b := &Writer[T]{
+		buf:     make([]T, size),
+		wr:      w,
+		flushOn: config.flushOn,
+	}
+
+	if config.flushInterval > 0 {
+		clock := config.clock
+		if clock == nil {
+			clock = gbuf.SystemClock()
+		}
+
+		b.mu = new(sync.Mutex)
+		b.done = make(chan struct{})
+		b.stopped = make(chan struct{})
+
+		go b.flushEvery(clock.NewTicker(config.flushInterval), b.done, b.stopped)
+	}
+
+	return b
+}
+
+// lock locks b.mu, if the Writer flushes periodically; plain Writers are not
+// safe for concurrent use, and do not pay for locking.
+func (b *Writer[T]) lock() {
+	if b.mu != nil {
+		b.mu.Lock()
+	}
+}
+
+func (b *Writer[T]) unlock() {
+	if b.mu != nil {
+		b.mu.Unlock()
+	}
+}
+
+// flushEvery flushes any buffered data on each tick, until the Writer is closed.
+func (b *Writer[T]) flushEvery(ticker gbuf.Ticker, done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.Chan():
+			b.lock()
+			// Errors are sticky, and returned on the next call to Write or Flush.
+			_ = b.flush()
+			b.unlock()
+		}
+	}
+}
+
+// Close stops the background flushing of a Writer configured with
+// WithFlushInterval, and flushes any buffered data. It does not close the
+// underlying gio.Writer. Calling Close more than once only flushes the Writer.
+func (b *Writer[T]) Close() error {
+	b.lock()
+	done := b.done
+	b.done = nil
+	b.unlock()
+
+	if done != nil {
+		close(done)
+		<-b.stopped
+	}
+
+	return b.Flush()
 }
 
 // Size returns the size of the underlying buffer.
 func (b *Writer[T]) Size() int { return len(b.buf) }
 
 // Reset discards any unflushed buffered data, clears any error, and
-// resets b to write its output to w.
+// resets b to write its output to w. If a write to the underlying writer was
+// abandoned by WriteContext or FlushContext, Reset first waits for it to return.
 // Calling Reset on the zero value of Writer initializes the internal buffer
 // to the default size.
 // Calling w.Reset(w) (that is, resetting a Writer to itself) does nothing.
@@ -65,6 +167,9 @@ func (b *Writer[T]) Reset(w gio.Writer[T]) {
 	if b == w {
 		return
 	}
+	b.lock()
+	defer b.unlock()
+	b.wait()
 	if b.buf == nil {
 		b.buf = make([]T, defaultBufSize)
 	}
@@ -75,13 +180,24 @@ func (b *Writer[T]) Reset(w gio.Writer[T]) {
 
 // Flush writes any buffered data to the underlying gio.Writer.
 func (b *Writer[T]) Flush() error {
+	b.lock()
+	defer b.unlock()
+
+	return b.flush()
+}
+
+func (b *Writer[T]) flush() error {
+	return b.flushContext(context.Background())
+}
+
+func (b *Writer[T]) flushContext(ctx context.Context) error {
 	if b.err != nil {
 		return b.err
 	}
 	if b.n == 0 {
 		return nil
 	}
-	n, err := b.wr.Write(b.buf[0:b.n])
+	n, err := b.writeContext(ctx, b.buf[0:b.n])
 	if n < b.n && err == nil {
 		err = io.ErrShortWrite
 	}
@@ -98,34 +214,59 @@ func (b *Writer[T]) Flush() error {
 }
 
 // Available returns how many items are unused in the buffer.
-func (b *Writer[T]) Available() int { return len(b.buf) - b.n }
+func (b *Writer[T]) Available() int {
+	b.lock()
+	defer b.unlock()
+
+	return b.available()
+}
+
+func (b *Writer[T]) available() int { return len(b.buf) - b.n }
 
 // AvailableBuffer returns an empty buffer with b.Available() capacity.
 // This buffer is intended to be appended to and
 // passed to an immediately succeeding Write call.
-// The buffer is only valid until the next write operation on b.
+// The buffer is only valid until the next write operation on b, or the
+// next periodic flush.
 func (b *Writer[T]) AvailableBuffer() []T {
+	b.lock()
+	defer b.unlock()
+
 	return b.buf[b.n:][:0]
 }
 
 // Buffered returns the number of item that have been written into the current buffer.
-func (b *Writer[T]) Buffered() int { return b.n }
+func (b *Writer[T]) Buffered() int {
+	b.lock()
+	defer b.unlock()
+
+	return b.n
+}
 
 // Write writes the contents of p into the buffer.
 // It returns the number of bytes written.
 // If nn < len(p), it also returns an error explaining
 // why the write is short.
 func (b *Writer[T]) Write(p []T) (nn int, err error) {
-	for len(p) > b.Available() && b.err == nil {
+	b.lock()
+	defer b.unlock()
+
+	return b.write(context.Background(), p)
+}
+
+func (b *Writer[T]) write(ctx context.Context, p []T) (nn int, err error) {
+	flush := b.flushOn != nil && slices.ContainsFunc(p, b.flushOn)
+
+	for len(p) > b.available() && b.err == nil {
 		var n int
-		if b.Buffered() == 0 {
+		if b.n == 0 {
 			// Large write, empty buffer.
 			// Write directly from p to avoid copy.
-			n, b.err = b.wr.Write(p)
+			n, b.err = b.writeContext(ctx, p)
 		} else {
 			n = copy(b.buf[b.n:], p)
 			b.n += n
-			b.Flush()
+			b.flushContext(ctx)
 		}
 		nn += n
 		p = p[n:]
@@ -136,19 +277,28 @@ func (b *Writer[T]) Write(p []T) (nn int, err error) {
 	n := copy(b.buf[b.n:], p)
 	b.n += n
 	nn += n
+	if flush {
+		return nn, b.flushContext(ctx)
+	}
 	return nn, nil
 }
 
 // WriteItem writes a single item.
 func (b *Writer[T]) WriteItem(c T) error {
+	b.lock()
+	defer b.unlock()
+
 	if b.err != nil {
 		return b.err
 	}
-	if b.Available() <= 0 && b.Flush() != nil {
+	if b.available() <= 0 && b.flush() != nil {
 		return b.err
 	}
 	b.buf[b.n] = c
 	b.n++
+	if b.flushOn != nil && b.flushOn(c) {
+		return b.flush()
+	}
 	return nil
 }
 
@@ -156,19 +306,25 @@ func (b *Writer[T]) WriteItem(c T) error {
 // supports the ReadFrom method, this calls the underlying ReadFrom.
 // If there is buffered data and an underlying ReadFrom, this fills
 // the buffer and writes it before calling ReadFrom.
+//
+// The Writer is locked for the duration of the call, deferring any periodic
+// flush until ReadFrom returns.
 func (b *Writer[T]) ReadFrom(r gio.Reader[T]) (n int64, err error) {
+	b.lock()
+	defer b.unlock()
+
 	if b.err != nil {
 		return 0, b.err
 	}
 	readerFrom, readerFromOK := b.wr.(gio.ReaderFrom[T])
 	var m int
 	for {
-		if b.Available() == 0 {
-			if err1 := b.Flush(); err1 != nil {
+		if b.available() == 0 {
+			if err1 := b.flush(); err1 != nil {
 				return n, err1
 			}
 		}
-		if readerFromOK && b.Buffered() == 0 {
+		if readerFromOK && b.n == 0 {
 			nn, err := readerFrom.ReadFrom(r)
 			b.err = err
 			n += nn
@@ -187,14 +343,19 @@ func (b *Writer[T]) ReadFrom(r gio.Reader[T]) (n int64, err error) {
 		}
 		b.n += m
 		n += int64(m)
+		if b.flushOn != nil && slices.ContainsFunc(b.buf[b.n-m:b.n], b.flushOn) {
+			if err1 := b.flush(); err1 != nil {
+				return n, err1
+			}
+		}
 		if err != nil {
 			break
 		}
 	}
 	if err == io.EOF {
 		// If we filled the buffer exactly, flush preemptively.
-		if b.Available() == 0 {
-			err = b.Flush()
+		if b.available() == 0 {
+			err = b.flush()
 		} else {
 			err = nil
 		}
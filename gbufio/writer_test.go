@@ -0,0 +1,131 @@
+package gbufio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gbuf"
+	"github.com/zalgonoise/gio"
+)
+
+// manualClock is a gbuf.Clock whose tickers only tick when sent to; its time
+// and timers are the system ones.
+type manualClock struct {
+	gbuf.Clock
+	ticker *manualTicker
+}
+
+func newManualClock(ticker *manualTicker) *manualClock {
+	return &manualClock{Clock: gbuf.SystemClock(), ticker: ticker}
+}
+
+func (c *manualClock) NewTicker(time.Duration) gbuf.Ticker {
+	return c.ticker
+}
+
+type manualTicker struct {
+	c       chan time.Time
+	stopped bool
+}
+
+func (t *manualTicker) Chan() <-chan time.Time { return t.c }
+func (t *manualTicker) Stop()                  { t.stopped = true }
+
+type failingWriter struct{}
+
+var errWriteFailed = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWriteFailed }
+
+func TestWriter_FlushInterval(t *testing.T) {
+	ticker := &manualTicker{c: make(chan time.Time)}
+	buf := gbuf.NewBuffer[byte](nil)
+	w := NewWriter[byte](buf, WithFlushInterval[byte](time.Second), WithClock[byte](newManualClock(ticker)))
+
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 5, w.Buffered())
+
+	ticker.c <- time.Now()
+	require.Eventually(t, func() bool { return w.Buffered() == 0 }, time.Second, time.Millisecond)
+	require.Equal(t, "hello", string(buf.Value()))
+
+	_, err = w.Write([]byte(" world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.True(t, ticker.stopped)
+	require.Equal(t, "hello world", string(buf.Value()))
+
+	// closing twice is a no-op
+	require.NoError(t, w.Close())
+}
+
+func TestWriter_FlushIntervalAvailableBuffer(t *testing.T) {
+	ticker := &manualTicker{c: make(chan time.Time)}
+	buf := gbuf.NewBuffer[byte](nil)
+	w := NewWriter[byte](buf, WithFlushInterval[byte](time.Second), WithClock[byte](newManualClock(ticker)))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 10 {
+			ticker.c <- time.Now()
+		}
+	}()
+
+	for i := range 10 {
+		p := append(w.AvailableBuffer(), byte('0'+i))
+		_, err := w.Write(p)
+		require.NoError(t, err)
+	}
+
+	<-done
+	require.NoError(t, w.Close())
+	require.Equal(t, "0123456789", string(buf.Value()))
+}
+
+func TestWriter_FlushIntervalError(t *testing.T) {
+	ticker := &manualTicker{c: make(chan time.Time)}
+	w := NewWriter[byte](failingWriter{}, WithFlushInterval[byte](time.Second), WithClock[byte](newManualClock(ticker)))
+
+	require.NoError(t, w.WriteItem('a'))
+
+	// the second tick is only received after the first flush completes
+	ticker.c <- time.Now()
+	ticker.c <- time.Now()
+	require.ErrorIs(t, w.WriteItem('b'), errWriteFailed)
+	require.ErrorIs(t, w.Close(), errWriteFailed)
+}
+
+// writerOnly hides any ReadFrom method from the wrapped writer.
+type writerOnly struct {
+	gio.Writer[byte]
+}
+
+func TestWriter_FlushOn(t *testing.T) {
+	buf := gbuf.NewBuffer[byte](nil)
+	w := NewWriter[byte](writerOnly{buf}, WithFlushOn[byte]('\n'))
+
+	_, err := w.Write([]byte("first"))
+	require.NoError(t, err)
+	require.Equal(t, 0, buf.Len())
+
+	_, err = w.Write([]byte(" line\nsecond"))
+	require.NoError(t, err)
+	require.Equal(t, "first line\nsecond", string(buf.Value()))
+	require.Equal(t, 0, w.Buffered())
+
+	require.NoError(t, w.WriteItem('!'))
+	require.Equal(t, 1, w.Buffered())
+	require.NoError(t, w.WriteItem('\n'))
+	require.Equal(t, 0, w.Buffered())
+
+	_, err = w.ReadFrom(oneItemReader[byte]{gbuf.NewReader([]byte("third\nfourth"))})
+	require.NoError(t, err)
+	require.Equal(t, "first line\nsecond!\nthird\n", string(buf.Value()))
+	require.Equal(t, 6, w.Buffered())
+}
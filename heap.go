@@ -46,7 +46,7 @@ func Push[T any](h Heap[T], x T) {
 // Pop removes and returns the minimum element (according to Less) from the heap.
 // The complexity is O(log n) where n = h.Len().
 // Pop is equivalent to Remove(h, 0).
-func Pop[T any](h Heap[T]) any {
+func Pop[T any](h Heap[T]) T {
 	n := h.Len() - 1
 	h.Swap(0, n)
 	down(h, 0, n)
@@ -56,7 +56,7 @@ func Pop[T any](h Heap[T]) any {
 
 // Remove removes and returns the element at index i from the heap.
 // The complexity is O(log n) where n = h.Len().
-func Remove[T any](h Heap[T], i int) any {
+func Remove[T any](h Heap[T], i int) T {
 	n := h.Len() - 1
 	if n != i {
 		h.Swap(i, n)
@@ -117,3 +117,23 @@ func down[T any](h Heap[T], i0, n int) bool {
 
 	return i > i0
 }
+
+// lessHeap implements Heap for a slice of T items ordered by a less function.
+type lessHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *lessHeap[T]) Len() int           { return len(h.items) }
+func (h *lessHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *lessHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *lessHeap[T]) Push(item T)        { h.items = append(h.items, item) }
+
+func (h *lessHeap[T]) Pop() T {
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items[n] = *new(T) // avoid memory leak
+	h.items = h.items[:n]
+
+	return item
+}
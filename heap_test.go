@@ -0,0 +1,149 @@
+package gbuf
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+const benchHeapSize = 10000
+
+func benchInput() []int {
+	rng := rand.New(rand.NewSource(0))
+	input := make([]int, benchHeapSize)
+
+	for i := range input {
+		input[i] = rng.Int()
+	}
+
+	return input
+}
+
+func BenchmarkHeap_PushPop(b *testing.B) {
+	input := benchInput()
+
+	b.Run("Binary", func(b *testing.B) {
+		h := make(intHeap, 0, benchHeapSize)
+
+		b.ResetTimer()
+
+		for range b.N {
+			for _, v := range input {
+				Push[int](&h, v)
+			}
+
+			for h.Len() > 0 {
+				Pop[int](&h)
+			}
+		}
+	})
+
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("Dary%d", d), func(b *testing.B) {
+			h := make(intHeap, 0, benchHeapSize)
+			dh := NewDaryHeap[int](&h, d)
+
+			b.ResetTimer()
+
+			for range b.N {
+				for _, v := range input {
+					dh.Push(v)
+				}
+
+				for dh.Len() > 0 {
+					dh.Pop()
+				}
+			}
+		})
+	}
+
+	b.Run("MinMax", func(b *testing.B) {
+		h := NewMinMaxHeap(intLess)
+		h.items = make([]int, 0, benchHeapSize)
+
+		b.ResetTimer()
+
+		for range b.N {
+			for _, v := range input {
+				h.Push(v)
+			}
+
+			for h.Len() > 0 {
+				h.PopMin()
+			}
+		}
+	})
+}
+
+func BenchmarkHeap_Init(b *testing.B) {
+	input := benchInput()
+
+	b.Run("Binary", func(b *testing.B) {
+		h := make(intHeap, benchHeapSize)
+
+		for range b.N {
+			copy(h, input)
+			Init[int](&h)
+		}
+	})
+
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("Dary%d", d), func(b *testing.B) {
+			h := make(intHeap, benchHeapSize)
+			dh := NewDaryHeap[int](&h, d)
+
+			for range b.N {
+				copy(h, input)
+				dh.Init()
+			}
+		})
+	}
+
+	b.Run("MinMax", func(b *testing.B) {
+		for range b.N {
+			NewMinMaxHeap(intLess, input...)
+		}
+	})
+}
+
+// BenchmarkHeap_DecreaseKey decreases random keys, as in Dijkstra's algorithm.
+func BenchmarkHeap_DecreaseKey(b *testing.B) {
+	input := benchInput()
+	rng := rand.New(rand.NewSource(1))
+	indices := make([]int, benchHeapSize)
+
+	for i := range indices {
+		indices[i] = rng.Intn(benchHeapSize)
+	}
+
+	b.Run("Binary", func(b *testing.B) {
+		h := make(intHeap, benchHeapSize)
+
+		for range b.N {
+			copy(h, input)
+			Init[int](&h)
+
+			for _, i := range indices {
+				h[i] /= 2
+				Fix[int](&h, i)
+			}
+		}
+	})
+
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("Dary%d", d), func(b *testing.B) {
+			h := make(intHeap, benchHeapSize)
+			dh := NewDaryHeap[int](&h, d)
+
+			for range b.N {
+				copy(h, input)
+				dh.Init()
+
+				for _, i := range indices {
+					h[i] /= 2
+					dh.Fix(i)
+				}
+			}
+		})
+	}
+}
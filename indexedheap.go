@@ -0,0 +1,143 @@
+package gbuf
+
+// IndexedHeap is a min-heap of unique keys of type K, ordered by a priority of
+// type P. Unlike a Heap, the position of each key is tracked internally, so its
+// priority can be updated, or the key removed, by identity (e.g. decreasing a
+// node's distance in Dijkstra's algorithm).
+//
+// The zero value is not usable; create one with NewIndexedHeap.
+type IndexedHeap[K comparable, P any] struct {
+	h indexedEntries[K, P]
+}
+
+// NewIndexedHeap creates an IndexedHeap ordered by less, which reports whether
+// priority a must be popped before priority b.
+func NewIndexedHeap[K comparable, P any](less func(a, b P) bool) *IndexedHeap[K, P] {
+	return &IndexedHeap[K, P]{
+		h: indexedEntries[K, P]{
+			index: make(map[K]int),
+			less:  less,
+		},
+	}
+}
+
+// Len returns the number of keys in the heap.
+func (h *IndexedHeap[K, P]) Len() int { return len(h.h.entries) }
+
+// Contains reports whether key is in the heap.
+func (h *IndexedHeap[K, P]) Contains(key K) bool {
+	_, ok := h.h.index[key]
+
+	return ok
+}
+
+// Priority returns the priority of key, and whether it is in the heap.
+func (h *IndexedHeap[K, P]) Priority(key K) (P, bool) {
+	i, ok := h.h.index[key]
+	if !ok {
+		return *new(P), false
+	}
+
+	return h.h.entries[i].prio, true
+}
+
+// Push adds key to the heap with priority prio. If key is already in the
+// heap, its priority is updated instead.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Push(key K, prio P) {
+	if h.Update(key, prio) {
+		return
+	}
+
+	Push[indexedEntry[K, P]](&h.h, indexedEntry[K, P]{key: key, prio: prio})
+}
+
+// Update sets the priority of key to prio, re-establishing the heap ordering.
+// It returns false if key is not in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Update(key K, prio P) bool {
+	i, ok := h.h.index[key]
+	if !ok {
+		return false
+	}
+
+	h.h.entries[i].prio = prio
+	Fix[indexedEntry[K, P]](&h.h, i)
+
+	return true
+}
+
+// Remove removes key from the heap, returning its priority. It returns false if
+// key is not in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Remove(key K) (P, bool) {
+	i, ok := h.h.index[key]
+	if !ok {
+		return *new(P), false
+	}
+
+	return Remove[indexedEntry[K, P]](&h.h, i).prio, true
+}
+
+// PeekMin returns the key with the minimum priority (according to less) and its
+// priority, without removing it. It returns false if the heap is empty.
+func (h *IndexedHeap[K, P]) PeekMin() (K, P, bool) {
+	if len(h.h.entries) == 0 {
+		return *new(K), *new(P), false
+	}
+
+	return h.h.entries[0].key, h.h.entries[0].prio, true
+}
+
+// PopMin removes and returns the key with the minimum priority (according to
+// less), and its priority. If the heap is empty, it returns zero values.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) PopMin() (K, P) {
+	if len(h.h.entries) == 0 {
+		return *new(K), *new(P)
+	}
+
+	entry := Pop[indexedEntry[K, P]](&h.h)
+
+	return entry.key, entry.prio
+}
+
+type indexedEntry[K comparable, P any] struct {
+	key  K
+	prio P
+}
+
+// indexedEntries implements Heap for the entries in an IndexedHeap, keeping
+// track of each key's index.
+type indexedEntries[K comparable, P any] struct {
+	entries []indexedEntry[K, P]
+	index   map[K]int
+	less    func(a, b P) bool
+}
+
+func (h *indexedEntries[K, P]) Len() int { return len(h.entries) }
+
+func (h *indexedEntries[K, P]) Less(i, j int) bool {
+	return h.less(h.entries[i].prio, h.entries[j].prio)
+}
+
+func (h *indexedEntries[K, P]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.index[h.entries[i].key] = i
+	h.index[h.entries[j].key] = j
+}
+
+func (h *indexedEntries[K, P]) Push(entry indexedEntry[K, P]) {
+	h.index[entry.key] = len(h.entries)
+	h.entries = append(h.entries, entry)
+}
+
+func (h *indexedEntries[K, P]) Pop() indexedEntry[K, P] {
+	n := len(h.entries) - 1
+	entry := h.entries[n]
+	h.entries[n] = indexedEntry[K, P]{} // avoid memory leak
+	h.entries = h.entries[:n]
+	delete(h.index, entry.key)
+
+	return entry
+}
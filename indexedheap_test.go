@@ -0,0 +1,101 @@
+package gbuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	h := NewIndexedHeap[string](func(a, b int) bool { return a < b })
+
+	h.Push("a", 5)
+	h.Push("b", 3)
+	h.Push("c", 8)
+	h.Push("d", 1)
+
+	require.Equal(t, 4, h.Len())
+	require.True(t, h.Contains("c"))
+	require.False(t, h.Contains("z"))
+
+	// decrease-key
+	require.True(t, h.Update("c", 0))
+	require.False(t, h.Update("z", 0))
+
+	key, prio, ok := h.PeekMin()
+	require.True(t, ok)
+	require.Equal(t, "c", key)
+	require.Equal(t, 0, prio)
+
+	// pushing an existing key updates it
+	h.Push("a", 2)
+	require.Equal(t, 4, h.Len())
+
+	prio, ok = h.Priority("a")
+	require.True(t, ok)
+	require.Equal(t, 2, prio)
+
+	prio, ok = h.Remove("d")
+	require.True(t, ok)
+	require.Equal(t, 1, prio)
+	require.False(t, h.Contains("d"))
+
+	_, ok = h.Remove("d")
+	require.False(t, ok)
+
+	var keys []string
+
+	for h.Len() > 0 {
+		key, _ := h.PopMin()
+		keys = append(keys, key)
+	}
+
+	require.Equal(t, []string{"c", "a", "b"}, keys)
+
+	key, prio = h.PopMin()
+	require.Zero(t, key)
+	require.Zero(t, prio)
+
+	_, _, ok = h.PeekMin()
+	require.False(t, ok)
+}
+
+func TestIndexedHeap_Dijkstra(t *testing.T) {
+	type edge struct {
+		to     string
+		weight int
+	}
+
+	graph := map[string][]edge{
+		"a": {{"b", 7}, {"c", 9}, {"f", 14}},
+		"b": {{"a", 7}, {"c", 10}, {"d", 15}},
+		"c": {{"a", 9}, {"b", 10}, {"d", 11}, {"f", 2}},
+		"d": {{"b", 15}, {"c", 11}, {"e", 6}},
+		"e": {{"d", 6}, {"f", 9}},
+		"f": {{"a", 14}, {"c", 2}, {"e", 9}},
+	}
+
+	dist := map[string]int{"a": 0}
+	visited := map[string]bool{}
+
+	h := NewIndexedHeap[string](func(a, b int) bool { return a < b })
+	h.Push("a", 0)
+
+	for h.Len() > 0 {
+		node, d := h.PopMin()
+		visited[node] = true
+
+		for _, e := range graph[node] {
+			if visited[e.to] {
+				continue
+			}
+
+			if cur, ok := dist[e.to]; !ok || d+e.weight < cur {
+				dist[e.to] = d + e.weight
+				h.Push(e.to, d+e.weight)
+			}
+		}
+	}
+
+	require.Equal(t, map[string]int{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}, dist)
+}
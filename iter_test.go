@@ -0,0 +1,88 @@
+package gbuf
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_All(t *testing.T) {
+	l := NewList[int]()
+	for i := range 5 {
+		l.PushBack(i)
+	}
+
+	require.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(l.All()))
+	require.Equal(t, []int{4, 3, 2, 1, 0}, slices.Collect(l.Backward()))
+
+	var first []int
+
+	for v := range l.Backward() {
+		first = append(first, v)
+
+		break
+	}
+
+	require.Equal(t, []int{4}, first)
+	require.Empty(t, slices.Collect(new(List[int]).All()))
+}
+
+func TestList_AllRemove(t *testing.T) {
+	l := NewList[int]()
+	elems := make(map[int]*Element[int])
+
+	for i := range 4 {
+		elems[i] = l.PushBack(i)
+	}
+
+	var seen []int
+
+	for v := range l.All() {
+		seen = append(seen, v)
+		l.Remove(elems[v])
+	}
+
+	require.Equal(t, []int{0, 1, 2, 3}, seen)
+	require.Zero(t, l.Len())
+}
+
+func TestRing_All(t *testing.T) {
+	r := NewRing[int](4)
+	for i := range 4 {
+		r.Value = i
+		r = r.Next()
+	}
+
+	require.Equal(t, []int{0, 1, 2, 3}, slices.Collect(r.All()))
+	require.Equal(t, []int{0, 3, 2, 1}, slices.Collect(r.Backward()))
+	require.Equal(t, []int{1, 2, 3, 0}, slices.Collect(r.Next().All()))
+
+	var nilRing *Ring[int]
+
+	require.Empty(t, slices.Collect(nilRing.All()))
+	require.Empty(t, slices.Collect(nilRing.Backward()))
+}
+
+func TestReader_All(t *testing.T) {
+	r := NewReader([]byte("xyabc"))
+
+	_, err := r.Read(make([]byte, 2))
+	require.NoError(t, err)
+
+	var forward, backward []byte
+
+	for i, item := range r.All() {
+		require.Equal(t, "abc"[i], item)
+
+		forward = append(forward, item)
+	}
+
+	for _, item := range r.Backward() {
+		backward = append(backward, item)
+	}
+
+	require.Equal(t, "abc", string(forward))
+	require.Equal(t, "cba", string(backward))
+	require.Equal(t, 3, r.Len())
+}
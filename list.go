@@ -1,5 +1,7 @@
 package gbuf
 
+import "iter"
+
 // Element is an element of a linked list.
 type Element[T any] struct {
 	// Next and previous pointers in the doubly-linked list of elements.
@@ -9,16 +11,24 @@ type Element[T any] struct {
 	// element (l.Front()).
 	next, prev *Element[T]
 
-	// The list to which this element belongs.
-	list *List[T]
+	// The identity of the list to which this element belongs.
+	id *listID[T]
 
 	// The value stored with this element.
 	Value T
 }
 
+// listID identifies a list. Moving all elements of a list into another one
+// points the former's listID at the latter's, instead of updating each element.
+type listID[T any] struct {
+	list   *List[T]
+	parent *listID[T]
+}
+
 // Next returns the next list element or nil.
 func (e *Element[T]) Next() *Element[T] {
-	if p := e.next; e.list != nil && p != &e.list.root {
+	// only the sentinel element of a list has no listID
+	if p := e.next; e.id != nil && p.id != nil {
 		return p
 	}
 
@@ -27,22 +37,43 @@ func (e *Element[T]) Next() *Element[T] {
 
 // Prev returns the previous list element or nil.
 func (e *Element[T]) Prev() *Element[T] {
-	if p := e.prev; e.list != nil && p != &e.list.root {
+	if p := e.prev; e.id != nil && p.id != nil {
 		return p
 	}
 
 	return nil
 }
 
+// owner returns the list to which e belongs, or nil. It shortens the chain of
+// listIDs left by moving whole lists, so later lookups are O(1).
+func (e *Element[T]) owner() *List[T] {
+	if e.id == nil {
+		return nil
+	}
+
+	for e.id.parent != nil {
+		e.id = e.id.parent
+	}
+
+	return e.id.list
+}
+
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
 type List[T any] struct {
 	root Element[T] // sentinel list element, only &root, root.prev, and root.next are used
 	len  int        // current list length excluding (this) sentinel element
+	id   *listID[T] // identity shared with the list's elements
 }
 
 // Init initializes or clears list l.
 func (l *List[T]) Init() *List[T] {
+	if l.id != nil {
+		// detach any elements from the cleared list
+		l.id.list = nil
+	}
+
+	l.id = &listID[T]{list: l}
 	l.root.next = &l.root
 	l.root.prev = &l.root
 	l.len = 0
@@ -88,7 +119,7 @@ func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
 	e.next = at.next
 	e.prev.next = e
 	e.next.prev = e
-	e.list = l
+	e.id = l.id
 	l.len++
 
 	return e
@@ -105,7 +136,7 @@ func (l *List[T]) remove(e *Element[T]) {
 	e.next.prev = e.prev
 	e.next = nil // avoid memory leaks
 	e.prev = nil // avoid memory leaks
-	e.list = nil
+	e.id = nil
 	l.len--
 }
 
@@ -128,8 +159,8 @@ func (l *List[T]) move(e, at *Element[T]) {
 // It returns the element value e.Value.
 // The element must not be nil.
 func (l *List[T]) Remove(e *Element[T]) any {
-	if e.list == l {
-		// if e.list == l, l must have been initialized when e was inserted
+	if e.owner() == l {
+		// if e.owner() == l, l must have been initialized when e was inserted
 		// in l or l == nil (e is a zero Element) and l.remove will crash
 		l.remove(e)
 	}
@@ -153,7 +184,7 @@ func (l *List[T]) PushBack(v T) *Element[T] {
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
 func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
-	if mark.list != l {
+	if mark.owner() != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
@@ -164,7 +195,7 @@ func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
 func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
-	if mark.list != l {
+	if mark.owner() != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
@@ -175,7 +206,7 @@ func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
 func (l *List[T]) MoveToFront(e *Element[T]) {
-	if e.list != l || l.root.next == e {
+	if e.owner() != l || l.root.next == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
@@ -186,7 +217,7 @@ func (l *List[T]) MoveToFront(e *Element[T]) {
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
 func (l *List[T]) MoveToBack(e *Element[T]) {
-	if e.list != l || l.root.prev == e {
+	if e.owner() != l || l.root.prev == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
@@ -197,7 +228,7 @@ func (l *List[T]) MoveToBack(e *Element[T]) {
 // If e or mark is not an element of l, or e == mark, the list is not modified.
 // The element and mark must not be nil.
 func (l *List[T]) MoveBefore(e, mark *Element[T]) {
-	if e.list != l || e == mark || mark.list != l {
+	if e.owner() != l || e == mark || mark.owner() != l {
 		return
 	}
 
@@ -208,7 +239,7 @@ func (l *List[T]) MoveBefore(e, mark *Element[T]) {
 // If e or mark is not an element of l, or e == mark, the list is not modified.
 // The element and mark must not be nil.
 func (l *List[T]) MoveAfter(e, mark *Element[T]) {
-	if e.list != l || e == mark || mark.list != l {
+	if e.owner() != l || e == mark || mark.owner() != l {
 		return
 	}
 
@@ -234,3 +265,272 @@ func (l *List[T]) PushFrontList(other *List[T]) {
 		l.insertValue(e.Value, &l.root)
 	}
 }
+
+// All returns an iterator over the values in list l, from front to back.
+// It is safe to remove the current element from l during iteration.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e.Value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the values in list l, from back to front.
+// It is safe to remove the current element from l during iteration.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(e.Value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// splice moves all elements of other next to at, and clears other.
+func (l *List[T]) splice(other *List[T], at *Element[T]) {
+	first, last := other.root.next, other.root.prev
+
+	// the elements of other now belong to l, via other's listID
+	other.id.parent = l.id
+
+	last.next = at.next
+	last.next.prev = last
+	at.next = first
+	first.prev = at
+	l.len += other.len
+
+	other.Init()
+}
+
+// SpliceAfter moves all elements of another list to their new position after
+// mark, leaving other empty. No elements are allocated or copied, and the
+// complexity is O(1).
+// If mark is not an element of l, or l and other are the same, the lists are
+// not modified. The mark and other must not be nil.
+func (l *List[T]) SpliceAfter(mark *Element[T], other *List[T]) {
+	if mark.owner() != l || other == l || other.len == 0 {
+		return
+	}
+
+	l.splice(other, mark)
+}
+
+// SpliceBefore moves all elements of another list to their new position before
+// mark, leaving other empty, like SpliceAfter.
+// If mark is not an element of l, or l and other are the same, the lists are
+// not modified. The mark and other must not be nil.
+func (l *List[T]) SpliceBefore(mark *Element[T], other *List[T]) {
+	if mark.owner() != l || other == l || other.len == 0 {
+		return
+	}
+
+	l.splice(other, mark.prev)
+}
+
+// SpliceFront moves all elements of another list to the front of list l,
+// leaving other empty, like SpliceAfter.
+// If l and other are the same, the list is not modified. other must not be nil.
+func (l *List[T]) SpliceFront(other *List[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+
+	l.lazyInit()
+	l.splice(other, &l.root)
+}
+
+// SpliceBack moves all elements of another list to the back of list l, leaving
+// other empty, like SpliceAfter.
+// If l and other are the same, the list is not modified. other must not be nil.
+func (l *List[T]) SpliceBack(other *List[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+
+	l.lazyInit()
+	l.splice(other, l.root.prev)
+}
+
+// Extract cuts the elements from `from` to `to` (inclusive) out of list l,
+// returning them as a new list, without allocating or copying elements. The
+// complexity is O(n), where n is the number of extracted elements.
+// If from or to is not an element of l, or to comes before from, the list is
+// not modified and Extract returns nil. The elements must not be nil.
+func (l *List[T]) Extract(from, to *Element[T]) *List[T] {
+	if from.owner() != l || to.owner() != l {
+		return nil
+	}
+
+	out := NewList[T]()
+
+	// reassign the elements while checking that to follows from, in one pass
+	for e := from; ; e = e.next {
+		if e == &l.root {
+			// to comes before from; undo the reassignment
+			for e = from; e != &l.root; e = e.next {
+				e.id = l.id
+			}
+
+			return nil
+		}
+
+		e.id = out.id
+		out.len++
+
+		if e == to {
+			break
+		}
+	}
+
+	from.prev.next = to.next
+	to.next.prev = from.prev
+	l.len -= out.len
+
+	out.root.next = from
+	out.root.prev = to
+	from.prev = &out.root
+	to.next = &out.root
+
+	return out
+}
+
+// Reverse reverses the order of the elements of list l, in place.
+func (l *List[T]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+
+	e := &l.root
+
+	for {
+		e.next, e.prev = e.prev, e.next
+		e = e.prev // the former next element
+
+		if e == &l.root {
+			return
+		}
+	}
+}
+
+// Sort sorts the elements of list l according to less, which reports whether a
+// must come before b. The sort is stable, and it is a bottom-up merge sort that
+// relinks the existing elements, without allocating.
+func (l *List[T]) Sort(less func(a, b T) bool) {
+	if l.len < 2 {
+		return
+	}
+
+	// detach the elements into a nil-terminated chain, linked by next
+	head := l.root.next
+	l.root.prev.next = nil
+
+	for size := 1; ; size *= 2 {
+		var (
+			p, tail = head, (*Element[T])(nil)
+			merges  int
+		)
+
+		head = nil
+
+		for p != nil {
+			merges++
+
+			// merge runs p and q, each of up to size elements
+			q, pSize, qSize := p, 0, size
+			for ; pSize < size && q != nil; pSize++ {
+				q = q.next
+			}
+
+			for pSize > 0 || (qSize > 0 && q != nil) {
+				var e *Element[T]
+
+				switch {
+				case pSize == 0:
+					e, q = q, q.next
+					qSize--
+				case qSize == 0 || q == nil, !less(q.Value, p.Value):
+					// taking from p on ties keeps the sort stable
+					e, p = p, p.next
+					pSize--
+				default:
+					e, q = q, q.next
+					qSize--
+				}
+
+				if tail == nil {
+					head = e
+				} else {
+					tail.next = e
+				}
+
+				tail = e
+			}
+
+			p = q
+		}
+
+		tail.next = nil
+
+		if merges <= 1 {
+			break
+		}
+	}
+
+	// restore the prev links and close the ring on the sentinel
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// Filter removes the elements of list l whose values do not satisfy keep,
+// returning the number of removed elements.
+func (l *List[T]) Filter(keep func(T) bool) (removed int) {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+
+		if !keep(e.Value) {
+			l.remove(e)
+			removed++
+		}
+
+		e = next
+	}
+
+	return removed
+}
+
+// FromSlice returns a new list holding the values in items, in order.
+func FromSlice[T any](items []T) *List[T] {
+	l := NewList[T]()
+
+	for i := range items {
+		l.insertValue(items[i], l.root.prev)
+	}
+
+	return l
+}
+
+// ToSlice returns the values in list l, from front to back.
+func (l *List[T]) ToSlice() []T {
+	items := make([]T, 0, l.len)
+
+	for e := l.Front(); e != nil; e = e.Next() {
+		items = append(items, e.Value)
+	}
+
+	return items
+}
@@ -0,0 +1,233 @@
+package gbuf
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// checkList verifies the links, ownership and length of list l, and that it
+// holds the values in wants.
+func checkList[T any](t *testing.T, l *List[T], wants []T) {
+	t.Helper()
+
+	require.Equal(t, len(wants), l.Len())
+
+	prev := &l.root
+	for e := l.root.next; e != &l.root; e = e.next {
+		require.Same(t, l, e.owner())
+		require.Same(t, prev, e.prev)
+		prev = e
+	}
+
+	require.Same(t, prev, l.root.prev)
+	require.Equal(t, wants, l.ToSlice())
+}
+
+func TestList_Splice(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		splice func(l, other *List[int])
+		wants  []int
+	}{
+		{
+			name:   "After",
+			splice: func(l, other *List[int]) { l.SpliceAfter(l.Front(), other) },
+			wants:  []int{1, 10, 11, 2, 3},
+		},
+		{
+			name:   "AfterBack",
+			splice: func(l, other *List[int]) { l.SpliceAfter(l.Back(), other) },
+			wants:  []int{1, 2, 3, 10, 11},
+		},
+		{
+			name:   "Before",
+			splice: func(l, other *List[int]) { l.SpliceBefore(l.Back(), other) },
+			wants:  []int{1, 2, 10, 11, 3},
+		},
+		{
+			name:   "Front",
+			splice: func(l, other *List[int]) { l.SpliceFront(other) },
+			wants:  []int{10, 11, 1, 2, 3},
+		},
+		{
+			name:   "Back",
+			splice: func(l, other *List[int]) { l.SpliceBack(other) },
+			wants:  []int{1, 2, 3, 10, 11},
+		},
+		{
+			name: "ForeignMark",
+			splice: func(l, other *List[int]) {
+				l.SpliceAfter(other.Front(), other)
+			},
+			wants: []int{1, 2, 3},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			l := FromSlice([]int{1, 2, 3})
+			other := FromSlice([]int{10, 11})
+			moved := other.Front()
+
+			testcase.splice(l, other)
+
+			checkList(t, l, testcase.wants)
+
+			if len(testcase.wants) == 5 {
+				checkList(t, other, []int{})
+				require.Same(t, l, moved.owner())
+			}
+		})
+	}
+
+	t.Run("Self", func(t *testing.T) {
+		l := FromSlice([]int{1, 2})
+		l.SpliceBack(l)
+		l.SpliceAfter(l.Front(), l)
+		checkList(t, l, []int{1, 2})
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		var l List[int]
+		l.SpliceBack(FromSlice([]int{1, 2}))
+		l.SpliceFront(new(List[int]))
+		checkList(t, &l, []int{1, 2})
+	})
+}
+
+func TestList_SpliceChained(t *testing.T) {
+	a := FromSlice([]int{1})
+	b := FromSlice([]int{2})
+	c := FromSlice([]int{3})
+	moved := a.Front()
+	id := moved.id
+
+	// splicing re-points the list identity, leaving the elements untouched
+	b.SpliceFront(a)
+	c.SpliceFront(b)
+	require.Same(t, id, moved.id)
+
+	checkList(t, c, []int{1, 2, 3})
+	require.Equal(t, 1, c.Remove(moved))
+	checkList(t, c, []int{2, 3})
+
+	// a spliced list is reusable, and its new elements are its own
+	a.PushBack(4)
+	checkList(t, a, []int{4})
+	c.SpliceAfter(a.Front(), a)
+	checkList(t, a, []int{4})
+
+	// clearing a list detaches its elements
+	front := c.Front()
+	c.Init()
+	require.Nil(t, front.owner())
+	c.MoveToBack(front)
+	require.Zero(t, c.Len())
+}
+
+func TestList_Extract(t *testing.T) {
+	l := FromSlice([]int{0, 1, 2, 3, 4})
+	from := l.Front().Next()
+	to := from.Next().Next()
+
+	// to comes before from
+	require.Nil(t, l.Extract(to, from))
+	require.Nil(t, l.Extract(from, NewList[int]().PushBack(9)))
+	checkList(t, l, []int{0, 1, 2, 3, 4})
+
+	out := l.Extract(from, to)
+	checkList(t, out, []int{1, 2, 3})
+	checkList(t, l, []int{0, 4})
+
+	single := l.Extract(l.Front(), l.Front())
+	checkList(t, single, []int{0})
+	checkList(t, l, []int{4})
+}
+
+func TestList_Reverse(t *testing.T) {
+	for _, items := range [][]int{{}, {1}, {1, 2}, {1, 2, 3, 4, 5}} {
+		l := FromSlice(items)
+		l.Reverse()
+
+		wants := slices.Clone(items)
+		slices.Reverse(wants)
+		checkList(t, l, wants)
+	}
+
+	var l List[int]
+	l.Reverse()
+	require.Zero(t, l.Len())
+}
+
+func TestList_Sort(t *testing.T) {
+	type pair struct {
+		key, order int
+	}
+
+	byKey := func(a, b pair) bool { return a.key < b.key }
+
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 100, 1000} {
+		items := make([]pair, n)
+		for i := range items {
+			items[i] = pair{key: r.Intn(10), order: i}
+		}
+
+		l := FromSlice(items)
+		elements := make(map[*Element[pair]]struct{}, n)
+
+		for e := l.Front(); e != nil; e = e.Next() {
+			elements[e] = struct{}{}
+		}
+
+		l.Sort(byKey)
+
+		wants := slices.Clone(items)
+		slices.SortStableFunc(wants, func(a, b pair) int { return a.key - b.key })
+		checkList(t, l, wants)
+
+		// the same elements are relinked
+		for e := l.Front(); e != nil; e = e.Next() {
+			_, ok := elements[e]
+			require.True(t, ok)
+		}
+	}
+}
+
+func TestList_SortAllocs(t *testing.T) {
+	l := FromSlice(rand.New(rand.NewSource(1)).Perm(256))
+	less := func(a, b int) bool { return a < b }
+
+	allocs := testing.AllocsPerRun(10, func() {
+		l.Sort(less)
+		l.Reverse()
+	})
+
+	require.Zero(t, allocs)
+}
+
+func TestList_Filter(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3, 4, 5, 6})
+	odd := l.Front()
+
+	require.Equal(t, 3, l.Filter(func(v int) bool { return v%2 == 0 }))
+	checkList(t, l, []int{2, 4, 6})
+	require.Nil(t, odd.owner())
+
+	require.Zero(t, l.Filter(func(int) bool { return true }))
+	require.Equal(t, 3, l.Filter(func(int) bool { return false }))
+	checkList(t, l, []int{})
+}
+
+func TestList_FromSlice(t *testing.T) {
+	require.Empty(t, FromSlice[int](nil).ToSlice())
+	require.Empty(t, new(List[int]).ToSlice())
+
+	items := []string{"a", "b", "c"}
+	l := FromSlice(items)
+	items[0] = "z"
+
+	checkList(t, l, []string{"a", "b", "c"})
+}
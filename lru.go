@@ -0,0 +1,389 @@
+package gbuf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zalgonoise/cfg"
+)
+
+// LRUConfig holds the settings for an LRU cache.
+type LRUConfig[K comparable, V any] struct {
+	onEvict func(key K, value V)
+	ttl     time.Duration
+	clock   Clock
+}
+
+func defaultLRUConfig[K comparable, V any]() LRUConfig[K, V] {
+	return LRUConfig[K, V]{
+		clock: systemClock{},
+	}
+}
+
+// WithOnEvict sets a function called with each entry evicted from an LRU cache,
+// either to make room for a new entry or because it expired.
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) cfg.Option[LRUConfig[K, V]] {
+	if fn == nil {
+		return cfg.NoOp[LRUConfig[K, V]]{}
+	}
+
+	return cfg.Register(func(c LRUConfig[K, V]) LRUConfig[K, V] {
+		c.onEvict = fn
+
+		return c
+	})
+}
+
+// WithTTL sets the time-to-live of the entries in an LRU cache, counted from
+// when each entry was last set with Put. Expired entries are evicted when they
+// are next accessed. Zero or negative values are ignored, and entries never
+// expire by default.
+func WithTTL[K comparable, V any](ttl time.Duration) cfg.Option[LRUConfig[K, V]] {
+	if ttl <= 0 {
+		return cfg.NoOp[LRUConfig[K, V]]{}
+	}
+
+	return cfg.Register(func(c LRUConfig[K, V]) LRUConfig[K, V] {
+		c.ttl = ttl
+
+		return c
+	})
+}
+
+// WithTTLClock sets the Clock used to expire the entries of an LRU cache (see
+// WithTTL). The default is the system clock.
+func WithTTLClock[K comparable, V any](clock Clock) cfg.Option[LRUConfig[K, V]] {
+	if clock == nil {
+		return cfg.NoOp[LRUConfig[K, V]]{}
+	}
+
+	return cfg.Register(func(c LRUConfig[K, V]) LRUConfig[K, V] {
+		c.clock = clock
+
+		return c
+	})
+}
+
+// LRU is a cache holding up to a set number of key-value pairs, evicting the
+// least recently used entry to make room for new ones. Entries are kept in a
+// List, from the most to the least recently used, and indexed by key.
+//
+// An LRU is not safe for concurrent use; see SyncLRU.
+//
+// The zero value is not usable; create one with NewLRU.
+type LRU[K comparable, V any] struct {
+	size    int
+	ll      *List[lruEntry[K, V]]
+	entries map[K]*Element[lruEntry[K, V]]
+	config  LRUConfig[K, V]
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewLRU creates an LRU cache holding up to size entries. If size is zero or
+// negative, the cache is unbounded.
+func NewLRU[K comparable, V any](size int, opts ...cfg.Option[LRUConfig[K, V]]) *LRU[K, V] {
+	if size < 0 {
+		size = 0
+	}
+
+	return &LRU[K, V]{
+		size:    size,
+		ll:      NewList[lruEntry[K, V]](),
+		entries: make(map[K]*Element[lruEntry[K, V]]),
+		config:  cfg.Set(defaultLRUConfig[K, V](), opts...),
+	}
+}
+
+// Len returns the number of entries in the cache, including any expired
+// entries that were not yet evicted.
+func (c *LRU[K, V]) Len() int { return c.ll.Len() }
+
+// Size returns the maximum number of entries in the cache, or zero if it is
+// unbounded.
+func (c *LRU[K, V]) Size() int { return c.size }
+
+// expired reports whether the entry in e is past its time-to-live.
+func (c *LRU[K, V]) expired(e *Element[lruEntry[K, V]]) bool {
+	return c.config.ttl > 0 && !c.config.clock.Now().Before(e.Value.expires)
+}
+
+// lookup returns the element for key, evicting it if it expired.
+func (c *LRU[K, V]) lookup(key K) (*Element[lruEntry[K, V]], bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.expired(e) {
+		c.evict(e)
+
+		return nil, false
+	}
+
+	return e, true
+}
+
+// Get returns the value for key, marking it as the most recently used entry.
+// It returns false if key is not in the cache, or if its entry expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		return *new(V), false
+	}
+
+	c.ll.MoveToFront(e)
+
+	return e.Value.value, true
+}
+
+// Peek returns the value for key, like Get, without marking it as recently used.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		return *new(V), false
+	}
+
+	return e.Value.value, true
+}
+
+// Contains reports whether key is in the cache, without marking it as recently
+// used.
+func (c *LRU[K, V]) Contains(key K) bool {
+	_, ok := c.lookup(key)
+
+	return ok
+}
+
+// Put sets the value for key, marking it as the most recently used entry and
+// resetting its time-to-live. If the cache is full, the least recently used
+// entry is evicted. It returns true if an entry was evicted.
+func (c *LRU[K, V]) Put(key K, value V) (evicted bool) {
+	var expires time.Time
+	if c.config.ttl > 0 {
+		expires = c.config.clock.Now().Add(c.config.ttl)
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.value = value
+		e.Value.expires = expires
+		c.ll.MoveToFront(e)
+
+		return false
+	}
+
+	c.entries[key] = c.ll.PushFront(lruEntry[K, V]{key: key, value: value, expires: expires})
+
+	return c.shrink() > 0
+}
+
+// Remove removes key from the cache, returning its value. It returns false if
+// key is not in the cache. The eviction callback is not called.
+func (c *LRU[K, V]) Remove(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return *new(V), false
+	}
+
+	c.ll.Remove(e)
+	delete(c.entries, key)
+
+	return e.Value.value, true
+}
+
+// Oldest returns the least recently used entry in the cache, without marking
+// it as recently used. It returns false if the cache is empty.
+func (c *LRU[K, V]) Oldest() (key K, value V, ok bool) {
+	for e := c.ll.Back(); e != nil; e = c.ll.Back() {
+		if !c.expired(e) {
+			return e.Value.key, e.Value.value, true
+		}
+
+		c.evict(e)
+	}
+
+	return key, value, false
+}
+
+// Resize changes the maximum number of entries in the cache, evicting the least
+// recently used entries if it holds more than size. If size is zero or
+// negative, the cache becomes unbounded. It returns the number of evicted
+// entries.
+func (c *LRU[K, V]) Resize(size int) (evicted int) {
+	if size < 0 {
+		size = 0
+	}
+
+	c.size = size
+
+	return c.shrink()
+}
+
+// Purge evicts all expired entries from the cache, returning how many were
+// evicted.
+func (c *LRU[K, V]) Purge() (evicted int) {
+	if c.config.ttl <= 0 {
+		return 0
+	}
+
+	for e := c.ll.Back(); e != nil; {
+		prev := e.Prev()
+
+		if c.expired(e) {
+			c.evict(e)
+			evicted++
+		}
+
+		e = prev
+	}
+
+	return evicted
+}
+
+// Clear removes all entries from the cache. The eviction callback is not
+// called.
+func (c *LRU[K, V]) Clear() {
+	c.ll.Init()
+	clear(c.entries)
+}
+
+// shrink evicts the least recently used entries until the cache fits its size.
+func (c *LRU[K, V]) shrink() (evicted int) {
+	for c.size > 0 && c.ll.Len() > c.size {
+		c.evict(c.ll.Back())
+		evicted++
+	}
+
+	return evicted
+}
+
+func (c *LRU[K, V]) evict(e *Element[lruEntry[K, V]]) {
+	c.ll.Remove(e)
+	delete(c.entries, e.Value.key)
+
+	if c.config.onEvict != nil {
+		c.config.onEvict(e.Value.key, e.Value.value)
+	}
+}
+
+// SyncLRU is an LRU cache safe for concurrent use. The eviction callback is
+// called while the cache is locked, so it must not call back into the cache.
+//
+// The zero value is not usable; create one with NewSyncLRU.
+type SyncLRU[K comparable, V any] struct {
+	mu  sync.Mutex
+	lru *LRU[K, V]
+}
+
+// NewSyncLRU creates a SyncLRU cache holding up to size entries. If size is zero
+// or negative, the cache is unbounded.
+func NewSyncLRU[K comparable, V any](size int, opts ...cfg.Option[LRUConfig[K, V]]) *SyncLRU[K, V] {
+	return &SyncLRU[K, V]{
+		lru: NewLRU[K, V](size, opts...),
+	}
+}
+
+// Len returns the number of entries in the cache, including any expired
+// entries that were not yet evicted.
+func (c *SyncLRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Size returns the maximum number of entries in the cache, or zero if it is
+// unbounded.
+func (c *SyncLRU[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Size()
+}
+
+// Get returns the value for key, marking it as the most recently used entry.
+// It returns false if key is not in the cache, or if its entry expired.
+func (c *SyncLRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Get(key)
+}
+
+// Peek returns the value for key, like Get, without marking it as recently used.
+func (c *SyncLRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Peek(key)
+}
+
+// Contains reports whether key is in the cache, without marking it as recently
+// used.
+func (c *SyncLRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Contains(key)
+}
+
+// Put sets the value for key, marking it as the most recently used entry and
+// resetting its time-to-live. If the cache is full, the least recently used
+// entry is evicted. It returns true if an entry was evicted.
+func (c *SyncLRU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Put(key, value)
+}
+
+// Remove removes key from the cache, returning its value. It returns false if
+// key is not in the cache. The eviction callback is not called.
+func (c *SyncLRU[K, V]) Remove(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Remove(key)
+}
+
+// Oldest returns the least recently used entry in the cache, without marking
+// it as recently used. It returns false if the cache is empty.
+func (c *SyncLRU[K, V]) Oldest() (K, V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Oldest()
+}
+
+// Resize changes the maximum number of entries in the cache, evicting the least
+// recently used entries if it holds more than size. If size is zero or
+// negative, the cache becomes unbounded. It returns the number of evicted
+// entries.
+func (c *SyncLRU[K, V]) Resize(size int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Resize(size)
+}
+
+// Purge evicts all expired entries from the cache, returning how many were
+// evicted.
+func (c *SyncLRU[K, V]) Purge() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Purge()
+}
+
+// Clear removes all entries from the cache. The eviction callback is not
+// called.
+func (c *SyncLRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Clear()
+}
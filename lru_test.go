@@ -0,0 +1,184 @@
+package gbuf
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type lruEviction struct {
+	key   string
+	value int
+}
+
+func lruKeys[K comparable, V any](c *LRU[K, V]) []K {
+	keys := make([]K, 0, c.Len())
+
+	for e := range c.ll.All() {
+		keys = append(keys, e.key)
+	}
+
+	return keys
+}
+
+func TestLRU(t *testing.T) {
+	var evicted []lruEviction
+
+	c := NewLRU[string, int](3, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, lruEviction{key, value})
+	}))
+
+	require.False(t, c.Put("a", 1))
+	require.False(t, c.Put("b", 2))
+	require.False(t, c.Put("c", 3))
+	require.Equal(t, []string{"c", "b", "a"}, lruKeys(c))
+
+	// Get marks as recently used; Peek does not
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	v, ok = c.Peek("b")
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	require.Equal(t, []string{"a", "c", "b"}, lruKeys(c))
+
+	require.True(t, c.Put("d", 4))
+	require.Equal(t, []lruEviction{{"b", 2}}, evicted)
+	require.False(t, c.Contains("b"))
+
+	// updating a key does not evict
+	require.False(t, c.Put("c", 30))
+	require.Equal(t, []string{"c", "d", "a"}, lruKeys(c))
+
+	key, value, ok := c.Oldest()
+	require.True(t, ok)
+	require.Equal(t, "a", key)
+	require.Equal(t, 1, value)
+
+	v, ok = c.Remove("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	_, ok = c.Remove("a")
+	require.False(t, ok)
+	require.Len(t, evicted, 1)
+
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 2, c.Len())
+
+	c.Clear()
+	require.Zero(t, c.Len())
+	_, _, ok = c.Oldest()
+	require.False(t, ok)
+}
+
+func TestLRU_Resize(t *testing.T) {
+	var evicted []string
+
+	c := NewLRU[string, int](0, WithOnEvict(func(key string, _ int) {
+		evicted = append(evicted, key)
+	}))
+
+	for i := range 5 {
+		c.Put(fmt.Sprint(i), i)
+	}
+
+	require.Equal(t, 5, c.Len())
+	require.Zero(t, c.Size())
+
+	require.Equal(t, 3, c.Resize(2))
+	require.Equal(t, []string{"0", "1", "2"}, evicted)
+	require.Equal(t, []string{"4", "3"}, lruKeys(c))
+	require.Equal(t, 2, c.Size())
+
+	require.Zero(t, c.Resize(10))
+	require.Equal(t, 2, c.Len())
+}
+
+func TestLRU_TTL(t *testing.T) {
+	var evicted []string
+
+	clock := newManualClock()
+	c := NewLRU[string, int](0,
+		WithTTL[string, int](time.Minute),
+		WithTTLClock[string, int](clock),
+		WithOnEvict(func(key string, _ int) { evicted = append(evicted, key) }),
+	)
+
+	c.Put("a", 1)
+	clock.Advance(30 * time.Second)
+	c.Put("b", 2)
+	c.Put("c", 3)
+
+	clock.Advance(30 * time.Second)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, []string{"a"}, evicted)
+
+	// Put resets the time-to-live
+	c.Put("b", 20)
+	clock.Advance(30 * time.Second)
+
+	require.Equal(t, 1, c.Purge())
+	require.Equal(t, []string{"a", "c"}, evicted)
+
+	v, ok := c.Peek("b")
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+
+	clock.Advance(30 * time.Second)
+
+	_, _, ok = c.Oldest()
+	require.False(t, ok)
+	require.Zero(t, c.Len())
+	require.Equal(t, []string{"a", "c", "b"}, evicted)
+}
+
+func TestSyncLRU(t *testing.T) {
+	const (
+		workers = 8
+		keys    = 100
+	)
+
+	c := NewSyncLRU[int, int](keys / 2)
+
+	var wg sync.WaitGroup
+
+	for w := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range keys {
+				c.Put((w+i)%keys, i)
+				c.Get(i)
+				c.Peek(keys - i)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, keys/2, c.Len())
+	require.Equal(t, keys/2, c.Size())
+
+	require.Equal(t, keys/2-10, c.Resize(10))
+	require.Zero(t, c.Purge())
+
+	key, _, ok := c.Oldest()
+	require.True(t, ok)
+	require.True(t, c.Contains(key))
+
+	_, ok = c.Remove(key)
+	require.True(t, ok)
+	require.Equal(t, 9, c.Len())
+
+	c.Clear()
+	require.Zero(t, c.Len())
+}
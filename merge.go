@@ -0,0 +1,202 @@
+package gbuf
+
+import (
+	"errors"
+	"io"
+
+	"github.com/zalgonoise/gio"
+)
+
+const (
+	// mergeReadSize is the number of items read at once from each source of a
+	// merged stream.
+	mergeReadSize = 64
+
+	maxConsecutiveEmptyReads = 100
+)
+
+// MergeSorted returns a gio.Reader that merges the items of the input readers,
+// each already sorted according to less, into a single sorted stream. The
+// readers may have different lengths; the merged stream ends once all of them
+// return io.EOF.
+//
+// MergeSorted keeps the head item of each reader in a heap, only reading more
+// items from a reader once its head is consumed. If any reader returns an error
+// other than io.EOF, the merged stream stops where that reader's next item would
+// be: the error is returned once the items already read from it are consumed,
+// and on every subsequent Read. The items of the other readers can't be ordered
+// against a missing head, so an error from a reader before its first item is
+// returned by the first Read, ahead of any items.
+//
+// Items that compare equal are returned in the order of the readers they were
+// read from.
+func MergeSorted[T any](less func(a, b T) bool, readers ...gio.Reader[T]) gio.Reader[T] {
+	m := &mergeReader[T]{
+		pending: make([]*mergeSource[T], 0, len(readers)),
+		h: mergeHeap[T]{
+			sources: make([]*mergeSource[T], 0, len(readers)),
+			less:    less,
+		},
+	}
+
+	for i := range readers {
+		m.pending = append(m.pending, &mergeSource[T]{r: readers[i], order: i})
+	}
+
+	return m
+}
+
+type mergeReader[T any] struct {
+	h       mergeHeap[T]
+	pending []*mergeSource[T] // sources yet to read their first item
+	err     error
+}
+
+// Read implements the gio.Reader interface, reading the next len(p) items of
+// the merged stream.
+func (m *mergeReader[T]) Read(p []T) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if len(m.pending) > 0 && m.err == nil {
+		m.init()
+	}
+
+	for n < len(p) && len(m.h.sources) > 0 && m.err == nil {
+		src := m.h.sources[0]
+		p[n] = src.buf[src.pos]
+		n++
+
+		if !m.advance(src) {
+			Pop[*mergeSource[T]](&m.h)
+
+			continue
+		}
+
+		Fix[*mergeSource[T]](&m.h, 0)
+	}
+
+	switch {
+	case n > 0:
+		return n, nil
+	case m.err != nil:
+		return 0, m.err
+	default:
+		return 0, io.EOF
+	}
+}
+
+// init reads the first item of each source, building the heap. It stops at the
+// first source that fails before returning any items.
+func (m *mergeReader[T]) init() {
+	for len(m.pending) > 0 {
+		src := m.pending[0]
+		src.buf = make([]T, mergeReadSize)
+
+		if !m.fill(src) && m.err != nil {
+			return
+		}
+
+		m.pending = m.pending[1:]
+
+		if src.pos < len(src.buf) {
+			m.h.sources = append(m.h.sources, src)
+		}
+	}
+
+	m.pending = nil
+	Init[*mergeSource[T]](&m.h)
+}
+
+// advance moves past the head item of src, reading more items if needed. It
+// returns false once src has no more items.
+func (m *mergeReader[T]) advance(src *mergeSource[T]) bool {
+	src.pos++
+	if src.pos < len(src.buf) {
+		return true
+	}
+
+	return m.fill(src)
+}
+
+// fill reads the next items from src, returning whether it has any. Once src
+// has no more items, any error other than io.EOF is recorded in m.err.
+func (m *mergeReader[T]) fill(src *mergeSource[T]) bool {
+	src.buf, src.pos = src.buf[:0], 0
+
+	if src.err != nil {
+		if !errors.Is(src.err, io.EOF) {
+			m.err = src.err
+		}
+
+		return false
+	}
+
+	buf := src.buf[:cap(src.buf)]
+
+	for i := maxConsecutiveEmptyReads; i > 0; i-- {
+		n, err := src.r.Read(buf)
+		if n < 0 {
+			m.err = ErrMergeNegativeRead
+
+			return false
+		}
+
+		src.buf = buf[:n]
+		src.err = err
+
+		switch {
+		case n > 0:
+			return true
+		case err != nil:
+			return m.fill(src)
+		}
+	}
+
+	m.err = io.ErrNoProgress
+
+	return false
+}
+
+type mergeSource[T any] struct {
+	r     gio.Reader[T]
+	buf   []T
+	pos   int
+	order int
+	err   error // error returned by the last Read, if any
+}
+
+// mergeHeap implements Heap for the sources of a merged stream, ordered by
+// their head items.
+type mergeHeap[T any] struct {
+	sources []*mergeSource[T]
+	less    func(a, b T) bool
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.sources) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.sources[i], h.sources[j]
+
+	switch {
+	case h.less(a.buf[a.pos], b.buf[b.pos]):
+		return true
+	case h.less(b.buf[b.pos], a.buf[a.pos]):
+		return false
+	default:
+		return a.order < b.order
+	}
+}
+
+func (h *mergeHeap[T]) Swap(i, j int)            { h.sources[i], h.sources[j] = h.sources[j], h.sources[i] }
+func (h *mergeHeap[T]) Push(src *mergeSource[T]) { h.sources = append(h.sources, src) }
+
+func (h *mergeHeap[T]) Pop() *mergeSource[T] {
+	n := len(h.sources) - 1
+	src := h.sources[n]
+	h.sources[n] = nil // avoid memory leak
+	h.sources = h.sources[:n]
+
+	return src
+}
@@ -0,0 +1,195 @@
+package gbuf
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gio"
+)
+
+type keyed struct {
+	key    int
+	source string
+}
+
+// errorReader returns its items and then err.
+type errorReader[T any] struct {
+	items []T
+	err   error
+}
+
+func (r *errorReader[T]) Read(p []T) (int, error) {
+	n := copy(p, r.items)
+	r.items = r.items[n:]
+
+	if len(r.items) == 0 {
+		return n, r.err
+	}
+
+	return n, nil
+}
+
+// smallReader returns one item per Read, to exercise refills.
+type smallReader[T any] struct {
+	items []T
+}
+
+func (r *smallReader[T]) Read(p []T) (int, error) {
+	if len(r.items) == 0 {
+		return 0, io.EOF
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	p[0] = r.items[0]
+	r.items = r.items[1:]
+
+	return 1, nil
+}
+
+func TestMergeSorted(t *testing.T) {
+	for _, testcase := range []struct {
+		name    string
+		readers func() []gio.Reader[int]
+		wants   []int
+	}{
+		{
+			name:    "NoReaders",
+			readers: func() []gio.Reader[int] { return nil },
+			wants:   []int{},
+		},
+		{
+			name: "EmptyReaders",
+			readers: func() []gio.Reader[int] {
+				return []gio.Reader[int]{NewReader[int](nil), NewReader([]int{})}
+			},
+			wants: []int{},
+		},
+		{
+			name: "DifferentLengths",
+			readers: func() []gio.Reader[int] {
+				return []gio.Reader[int]{
+					NewReader([]int{1, 4, 7, 10, 11, 12}),
+					NewReader([]int{2}),
+					NewReader[int](nil),
+					NewReader([]int{0, 3, 5, 6, 8, 9}),
+				}
+			},
+			wants: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+		},
+		{
+			name: "Refills",
+			readers: func() []gio.Reader[int] {
+				return []gio.Reader[int]{
+					&smallReader[int]{[]int{1, 3, 5}},
+					&smallReader[int]{[]int{2, 4, 6}},
+				}
+			},
+			wants: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name: "Duplicates",
+			readers: func() []gio.Reader[int] {
+				return []gio.Reader[int]{NewReader([]int{1, 1, 2}), NewReader([]int{1, 2, 2})}
+			},
+			wants: []int{1, 1, 1, 2, 2, 2},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			items, err := gio.ReadAll(MergeSorted(intLess, testcase.readers()...))
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, items)
+		})
+	}
+}
+
+func TestMergeSorted_Large(t *testing.T) {
+	const n = 1000
+
+	evens, odds := make([]int, 0, n), make([]int, 0, n)
+	for i := range n {
+		evens = append(evens, 2*i)
+		odds = append(odds, 2*i+1)
+	}
+
+	r := MergeSorted(intLess, gio.Reader[int](NewReader(evens)), NewReader(odds))
+	p := make([]int, 7) // not aligned with the sources' reads
+
+	var merged []int
+
+	for {
+		m, err := r.Read(p)
+		merged = append(merged, p[:m]...)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	require.Len(t, merged, 2*n)
+
+	for i := range merged {
+		require.Equal(t, i, merged[i])
+	}
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	r := MergeSorted(
+		func(a, b keyed) bool { return a.key < b.key },
+		gio.Reader[keyed](NewReader([]keyed{{1, "a"}, {2, "a"}})),
+		NewReader([]keyed{{1, "b"}, {2, "b"}}),
+		NewReader([]keyed{{1, "c"}}),
+	)
+
+	items, err := gio.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, []keyed{{1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "b"}}, items)
+}
+
+func TestMergeSorted_Error(t *testing.T) {
+	errBroken := errors.New("broken source")
+
+	r := MergeSorted(intLess,
+		gio.Reader[int](NewReader([]int{1, 3, 5, 7})),
+		&errorReader[int]{items: []int{2, 4}, err: errBroken},
+		&errorReader[int]{items: []int{0}, err: io.EOF},
+	)
+
+	items, err := gio.ReadAll(r)
+	require.ErrorIs(t, err, errBroken)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, items)
+
+	// the error is sticky
+	_, err = r.Read(make([]int, 1))
+	require.ErrorIs(t, err, errBroken)
+}
+
+func TestMergeSorted_ErrorFirstRead(t *testing.T) {
+	errBroken := errors.New("broken source")
+
+	r := MergeSorted(intLess,
+		gio.Reader[int](NewReader([]int{1, 3})),
+		&errorReader[int]{err: errBroken},
+		&errorReader[int]{items: []int{0}, err: io.EOF},
+	)
+
+	n, err := r.Read(make([]int, 4))
+	require.ErrorIs(t, err, errBroken)
+	require.Zero(t, n)
+
+	// an error returned with the first items is held until they are consumed
+	r = MergeSorted(intLess,
+		gio.Reader[int](NewReader([]int{1, 3})),
+		&errorReader[int]{items: []int{2}, err: errBroken},
+	)
+
+	items, err := gio.ReadAll(r)
+	require.ErrorIs(t, err, errBroken)
+	require.Equal(t, []int{1, 2}, items)
+}
@@ -0,0 +1,213 @@
+package gbuf
+
+import "math/bits"
+
+// MinMaxHeap is a double-ended priority queue of T items, ordered by a less
+// function, with O(1) access to both its minimum and maximum items, and
+// O(log n) removal of either.
+//
+// Items are kept in an array where the levels of the implicit binary tree
+// alternate between min levels (starting with the root) and max levels: each
+// item on a min level is smaller than all of its descendants, and each item
+// on a max level is larger than all of its descendants.
+//
+// The zero value is not usable; create one with NewMinMaxHeap.
+type MinMaxHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewMinMaxHeap creates a MinMaxHeap ordered by less, which reports whether a
+// is smaller than b. Any items are added to the heap in O(n).
+func NewMinMaxHeap[T any](less func(a, b T) bool, items ...T) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{
+		items: make([]T, len(items)),
+		less:  less,
+	}
+
+	copy(h.items, items)
+
+	for i := len(h.items)/half - 1; i >= 0; i-- {
+		h.down(i)
+	}
+
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *MinMaxHeap[T]) Len() int { return len(h.items) }
+
+// Push adds item to the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *MinMaxHeap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Min returns the smallest item in the heap (according to less), without
+// removing it. It returns false if the heap is empty.
+func (h *MinMaxHeap[T]) Min() (T, bool) {
+	if len(h.items) == 0 {
+		return *new(T), false
+	}
+
+	return h.items[0], true
+}
+
+// Max returns the largest item in the heap (according to less), without
+// removing it. It returns false if the heap is empty.
+func (h *MinMaxHeap[T]) Max() (T, bool) {
+	if len(h.items) == 0 {
+		return *new(T), false
+	}
+
+	return h.items[h.maxIndex()], true
+}
+
+// PopMin removes and returns the smallest item in the heap (according to
+// less). It returns false if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.items) == 0 {
+		return *new(T), false
+	}
+
+	return h.remove(0), true
+}
+
+// PopMax removes and returns the largest item in the heap (according to
+// less). It returns false if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.items) == 0 {
+		return *new(T), false
+	}
+
+	return h.remove(h.maxIndex()), true
+}
+
+// maxIndex returns the index of the largest item, which is one of the root's
+// children, or the root itself. The heap must not be empty.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	default:
+		if h.less(h.items[1], h.items[2]) {
+			return 2
+		}
+
+		return 1
+	}
+}
+
+// remove removes and returns the item at index i, which must be either the
+// root or one of its children.
+func (h *MinMaxHeap[T]) remove(i int) T {
+	n := len(h.items) - 1
+	item := h.items[i]
+
+	h.items[i] = h.items[n]
+	h.items[n] = *new(T) // avoid memory leak
+	h.items = h.items[:n]
+
+	if i < n {
+		h.down(i)
+	}
+
+	return item
+}
+
+// isMinLevel reports whether index i is on a min level of the tree.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// ordered reports whether items i and j are in the order of the level of i:
+// i is smaller than j on a min level, or larger than j on a max level.
+func (h *MinMaxHeap[T]) ordered(i, j int, minLevel bool) bool {
+	if minLevel {
+		return h.less(h.items[i], h.items[j])
+	}
+
+	return h.less(h.items[j], h.items[i])
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *MinMaxHeap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+
+	minLevel := isMinLevel(i)
+	parent := (i - 1) / half
+
+	// an item out of order with its parent moves to the parent's levels
+	if h.ordered(parent, i, minLevel) {
+		h.swap(i, parent)
+		h.upLevels(parent, !minLevel)
+
+		return
+	}
+
+	h.upLevels(i, minLevel)
+}
+
+// upLevels moves the item at index i up through its grandparents, which are
+// on the same kind of level.
+func (h *MinMaxHeap[T]) upLevels(i int, minLevel bool) {
+	for i > 2 {
+		grandparent := ((i-1)/half - 1) / half
+
+		if !h.ordered(i, grandparent, minLevel) {
+			return
+		}
+
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+func (h *MinMaxHeap[T]) down(i int) {
+	minLevel := isMinLevel(i)
+	n := len(h.items)
+
+	for {
+		// find the smallest (or largest, on a max level) child or grandchild
+		first := double*i + 1
+		if first >= n {
+			return
+		}
+
+		m := first
+
+		for _, j := range [...]int{first + 1, double*first + 1, double*first + 2, double*first + 3, double*first + 4} {
+			if j < n && h.ordered(j, m, minLevel) {
+				m = j
+			}
+		}
+
+		if !h.ordered(m, i, minLevel) {
+			return
+		}
+
+		h.swap(m, i)
+
+		if m <= first+1 {
+			// a child, which has no descendants on the same kind of level
+			return
+		}
+
+		// a grandchild, which may now be out of order with its parent
+		if parent := (m - 1) / half; h.ordered(parent, m, minLevel) {
+			h.swap(m, parent)
+		}
+
+		i = m
+	}
+}
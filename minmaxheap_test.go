@@ -0,0 +1,113 @@
+package gbuf
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		init  []int
+		push  []int
+		wants []int
+	}{
+		{
+			name:  "Empty",
+			wants: []int{},
+		},
+		{
+			name:  "Single",
+			push:  []int{1},
+			wants: []int{1},
+		},
+		{
+			name:  "Two",
+			init:  []int{2, 1},
+			wants: []int{1, 2},
+		},
+		{
+			name:  "InitAndPush",
+			init:  []int{9, 4, 7, 1, 8, 2, 2},
+			push:  []int{6, 0, 3, 10, 5},
+			wants: []int{0, 1, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			h := NewMinMaxHeap(intLess, testcase.init...)
+
+			for _, item := range testcase.push {
+				h.Push(item)
+			}
+
+			require.Equal(t, len(testcase.wants), h.Len())
+
+			// alternate between both ends
+			lo, hi := 0, len(testcase.wants)-1
+
+			for i := 0; h.Len() > 0; i++ {
+				if i%2 == 0 {
+					v, ok := h.Min()
+					require.True(t, ok)
+					require.Equal(t, testcase.wants[lo], v)
+
+					v, ok = h.PopMin()
+					require.True(t, ok)
+					require.Equal(t, testcase.wants[lo], v)
+					lo++
+
+					continue
+				}
+
+				v, ok := h.Max()
+				require.True(t, ok)
+				require.Equal(t, testcase.wants[hi], v)
+
+				v, ok = h.PopMax()
+				require.True(t, ok)
+				require.Equal(t, testcase.wants[hi], v)
+				hi--
+			}
+
+			_, ok := h.Min()
+			require.False(t, ok)
+			_, ok = h.Max()
+			require.False(t, ok)
+			_, ok = h.PopMin()
+			require.False(t, ok)
+			_, ok = h.PopMax()
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestMinMaxHeap_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewMinMaxHeap(intLess)
+
+	var model []int
+
+	for range 5000 {
+		switch op := rng.Intn(4); {
+		case op < 2 || len(model) == 0:
+			v := rng.Intn(100)
+			h.Push(v)
+			model = append(model, v)
+		case op == 2:
+			v, ok := h.PopMin()
+			require.True(t, ok)
+			require.Equal(t, slices.Min(model), v)
+			model = slices.Delete(model, slices.Index(model, v), slices.Index(model, v)+1)
+		default:
+			v, ok := h.PopMax()
+			require.True(t, ok)
+			require.Equal(t, slices.Max(model), v)
+			model = slices.Delete(model, slices.Index(model, v), slices.Index(model, v)+1)
+		}
+
+		require.Equal(t, len(model), h.Len())
+	}
+}
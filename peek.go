@@ -13,7 +13,7 @@ func Peek[T any](p []T, b *Buffer[T]) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if len(p) == 0 {
 			return 0, nil
@@ -39,7 +39,7 @@ func PeekFrom[T any](idx int, p []T, b *Buffer[T]) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if len(p) == 0 {
 			return 0, nil
@@ -83,7 +83,7 @@ func PeekRange[T any](from, to int, p []T, b *Buffer[T]) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if ln == 0 {
 			return 0, nil
@@ -17,7 +17,7 @@ type PeekBuffer[T any] struct {
 func (b *PeekBuffer[T]) Peek(p []T) (n int, err error) {
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if len(p) == 0 {
 			return 0, nil
@@ -39,7 +39,7 @@ func (b *PeekBuffer[T]) PeekFrom(idx int, p []T) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if len(p) == 0 {
 			return 0, nil
@@ -79,7 +79,7 @@ func (b *PeekBuffer[T]) PeekRange(from, to int, p []T) (n int, err error) {
 
 	if b.empty() {
 		// Buffer is empty, reset to recover space.
-		b.Reset()
+		b.resetEmpty()
 
 		if ln == 0 {
 			return 0, nil
@@ -0,0 +1,137 @@
+package gbuf
+
+// PriorityItem is a handle to an item in a PriorityQueue, as returned by its
+// Push method. It can be used to Fix the item's position after changing its
+// Value, or to Remove it from the queue.
+type PriorityItem[T any] struct {
+	// The value stored with this item.
+	Value T
+
+	queue *PriorityQueue[T]
+	index int
+}
+
+// PriorityQueue is a min-heap of T items, ordered by a less function, so that
+// Pop always returns the smallest item in the queue.
+//
+// Unlike the Heap routines, a PriorityQueue does not require implementing
+// sort.Interface. The zero value is not usable; create one with
+// NewPriorityQueue.
+type PriorityQueue[T any] struct {
+	h priorityHeap[T]
+}
+
+// NewPriorityQueue creates a PriorityQueue ordered by less, which reports
+// whether a must be popped before b. Any items are added to the queue in O(n).
+func NewPriorityQueue[T any](less func(a, b T) bool, items ...T) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{
+		h: priorityHeap[T]{
+			items: make([]*PriorityItem[T], 0, len(items)),
+			less:  less,
+		},
+	}
+
+	for i := range items {
+		q.h.items = append(q.h.items, &PriorityItem[T]{Value: items[i], queue: q, index: i})
+	}
+
+	Init[*PriorityItem[T]](&q.h)
+
+	return q
+}
+
+// Len returns the number of items in the queue.
+func (q *PriorityQueue[T]) Len() int { return len(q.h.items) }
+
+// Push adds v to the queue, returning its handle.
+// The complexity is O(log n) where n = q.Len().
+func (q *PriorityQueue[T]) Push(v T) *PriorityItem[T] {
+	item := &PriorityItem[T]{Value: v, queue: q}
+
+	Push[*PriorityItem[T]](&q.h, item)
+
+	return item
+}
+
+// Pop removes and returns the smallest item in the queue (according to less).
+// It returns false if the queue is empty.
+// The complexity is O(log n) where n = q.Len().
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if len(q.h.items) == 0 {
+		return *new(T), false
+	}
+
+	return Pop[*PriorityItem[T]](&q.h).Value, true
+}
+
+// Peek returns the smallest item in the queue (according to less), without
+// removing it. It returns false if the queue is empty.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.h.items) == 0 {
+		return *new(T), false
+	}
+
+	return q.h.items[0].Value, true
+}
+
+// Fix re-establishes the ordering of the queue after the Value of item has
+// changed. It returns false if item is not in the queue.
+// The complexity is O(log n) where n = q.Len().
+func (q *PriorityQueue[T]) Fix(item *PriorityItem[T]) bool {
+	if !q.contains(item) {
+		return false
+	}
+
+	Fix[*PriorityItem[T]](&q.h, item.index)
+
+	return true
+}
+
+// Remove removes item from the queue, returning its value. It returns false if
+// item is not in the queue.
+// The complexity is O(log n) where n = q.Len().
+func (q *PriorityQueue[T]) Remove(item *PriorityItem[T]) (T, bool) {
+	if !q.contains(item) {
+		return *new(T), false
+	}
+
+	return Remove[*PriorityItem[T]](&q.h, item.index).Value, true
+}
+
+func (q *PriorityQueue[T]) contains(item *PriorityItem[T]) bool {
+	return item != nil && item.queue == q && item.index >= 0
+}
+
+// priorityHeap implements Heap for the items in a PriorityQueue, keeping track
+// of each item's index.
+type priorityHeap[T any] struct {
+	items []*PriorityItem[T]
+	less  func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int { return len(h.items) }
+
+func (h *priorityHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[i].Value, h.items[j].Value)
+}
+
+func (h *priorityHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *priorityHeap[T]) Push(item *PriorityItem[T]) {
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *priorityHeap[T]) Pop() *PriorityItem[T] {
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items[n] = nil // avoid memory leak
+	h.items = h.items[:n]
+	item.index = -1 // for safety
+
+	return item
+}
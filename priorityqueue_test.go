@@ -0,0 +1,152 @@
+package gbuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func byPriority(a, b task) bool { return a.priority < b.priority }
+
+func drain[T any](q *PriorityQueue[T]) []T {
+	items := make([]T, 0, q.Len())
+
+	for {
+		item, ok := q.Pop()
+		if !ok {
+			return items
+		}
+
+		items = append(items, item)
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		init  []int
+		push  []int
+		wants []int
+	}{
+		{
+			name:  "Empty",
+			wants: []int{},
+		},
+		{
+			name:  "Init",
+			init:  []int{5, 3, 8, 1, 9, 2},
+			wants: []int{1, 2, 3, 5, 8, 9},
+		},
+		{
+			name:  "Push",
+			push:  []int{5, 3, 8, 1, 9, 2},
+			wants: []int{1, 2, 3, 5, 8, 9},
+		},
+		{
+			name:  "InitAndPush",
+			init:  []int{4, 4, 7},
+			push:  []int{0, 4, 10},
+			wants: []int{0, 4, 4, 4, 7, 10},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			q := NewPriorityQueue(func(a, b int) bool { return a < b }, testcase.init...)
+
+			for _, v := range testcase.push {
+				q.Push(v)
+			}
+
+			require.Equal(t, len(testcase.wants), q.Len())
+
+			if len(testcase.wants) > 0 {
+				v, ok := q.Peek()
+				require.True(t, ok)
+				require.Equal(t, testcase.wants[0], v)
+			}
+
+			require.Equal(t, testcase.wants, drain(q))
+
+			_, ok := q.Peek()
+			require.False(t, ok)
+			_, ok = q.Pop()
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestPriorityQueue_FixRemove(t *testing.T) {
+	q := NewPriorityQueue(byPriority)
+
+	low := q.Push(task{"low", 10})
+	mid := q.Push(task{"mid", 5})
+	q.Push(task{"high", 1})
+
+	low.Value.priority = 0
+	require.True(t, q.Fix(low))
+
+	top, ok := q.Peek()
+	require.True(t, ok)
+	require.Equal(t, "low", top.name)
+
+	removed, ok := q.Remove(mid)
+	require.True(t, ok)
+	require.Equal(t, "mid", removed.name)
+
+	// handles are no longer valid once removed or popped
+	_, ok = q.Remove(mid)
+	require.False(t, ok)
+	require.False(t, q.Fix(mid))
+
+	_, ok = q.Pop()
+	require.True(t, ok)
+	require.False(t, q.Fix(low))
+
+	// handles from other queues are rejected
+	other := NewPriorityQueue(byPriority)
+	_, ok = other.Remove(other.Push(task{"other", 0}))
+	require.True(t, ok)
+
+	foreign := other.Push(task{"foreign", 0})
+	_, ok = q.Remove(foreign)
+	require.False(t, ok)
+
+	tasks := drain(q)
+	require.Len(t, tasks, 1)
+	require.Equal(t, "high", tasks[0].name)
+	require.Equal(t, 1, other.Len())
+}
+
+type intHeap []int
+
+func (h *intHeap) Len() int           { return len(*h) }
+func (h *intHeap) Less(i, j int) bool { return (*h)[i] < (*h)[j] }
+func (h *intHeap) Swap(i, j int)      { (*h)[i], (*h)[j] = (*h)[j], (*h)[i] }
+func (h *intHeap) Push(x int)         { *h = append(*h, x) }
+
+func (h *intHeap) Pop() int {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+
+	return x
+}
+
+func TestHeap_PopRemove(t *testing.T) {
+	h := &intHeap{5, 2, 8, 1}
+	Init[int](h)
+
+	var smallest int = Pop[int](h)
+	require.Equal(t, 1, smallest)
+
+	Push[int](h, 0)
+
+	removed := Remove[int](h, h.Len()-1)
+	require.Contains(t, []int{2, 5, 8}, removed)
+	require.Equal(t, 0, Pop[int](h))
+}
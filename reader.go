@@ -2,6 +2,7 @@ package gbuf
 
 import (
 	"io"
+	"iter"
 
 	"github.com/zalgonoise/gio"
 )
@@ -146,3 +147,37 @@ func (r *Reader[T]) Reset(b []T) { *r = Reader[T]{b, 0, -1} }
 
 // NewReader returns a new Reader reading from b.
 func NewReader[T any](b []T) *Reader[T] { return &Reader[T]{b, 0, -1} }
+
+// All returns an iterator over the index-value pairs of the unread portion of
+// the slice, in order, without advancing the reader.
+func (r *Reader[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		if r.i >= int64(len(r.s)) {
+			return
+		}
+
+		for i, item := range r.s[r.i:] {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index-value pairs of the unread portion
+// of the slice, in reverse order, without advancing the reader.
+func (r *Reader[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		if r.i >= int64(len(r.s)) {
+			return
+		}
+
+		items := r.s[r.i:]
+
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(i, items[i]) {
+				return
+			}
+		}
+	}
+}
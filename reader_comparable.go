@@ -0,0 +1,65 @@
+package gbuf
+
+import "io"
+
+// ComparableReader is a Reader for types that allow comparisons, unlocking search
+// features over multi-item separators, such as CRLF sequences or frame markers.
+type ComparableReader[T comparable] struct {
+	*Reader[T]
+}
+
+// Index returns the index of the first instance of sep in the unread portion of the
+// slice, or -1 if sep is not present.
+func (r *ComparableReader[T]) Index(sep []T) int {
+	if r.i >= int64(len(r.s)) {
+		return Index(nil, sep)
+	}
+
+	return Index(r.s[r.i:], sep)
+}
+
+// Contains reports whether sep is within the unread portion of the slice.
+func (r *ComparableReader[T]) Contains(sep []T) bool {
+	return r.Index(sep) >= 0
+}
+
+// ReadUntil reads until the first occurrence of sep in the input,
+// returning a slice containing the data up to and including the separator.
+// If ReadUntil encounters an error before finding a separator,
+// it returns the data read before the error and the error itself (often io.EOF).
+// ReadUntil returns err != nil if and only if the returned data does not end in sep.
+// An empty sep is rejected with ErrReaderInvalidSeparator, without reading.
+func (r *ComparableReader[T]) ReadUntil(sep []T) (line []T, err error) {
+	if len(sep) == 0 {
+		return nil, ErrReaderInvalidSeparator
+	}
+
+	r.prevRune = -1
+
+	if r.i >= int64(len(r.s)) {
+		return nil, io.EOF
+	}
+
+	end := int64(len(r.s))
+
+	if i := r.Index(sep); i >= 0 {
+		end = r.i + int64(i+len(sep))
+	} else {
+		err = io.EOF
+	}
+
+	line = append(line, r.s[r.i:end]...)
+	r.i = end
+
+	return line, err
+}
+
+// NewComparableReader returns a new ComparableReader reading from b.
+func NewComparableReader[T comparable](b []T) *ComparableReader[T] {
+	return &ComparableReader[T]{NewReader(b)}
+}
+
+// AsComparableReader converts a Reader[T] into a ComparableReader[T]
+func AsComparableReader[T comparable](r *Reader[T]) *ComparableReader[T] {
+	return &ComparableReader[T]{r}
+}
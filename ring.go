@@ -1,5 +1,7 @@
 package gbuf
 
+import "iter"
+
 // A Ring is an element of a circular list, or ring.
 // Rings do not have a beginning or end; a pointer to any ring element
 // serves as reference to the entire ring. Empty rings are represented
@@ -143,3 +145,45 @@ func (r *Ring[T]) Do(f func(T)) {
 		}
 	}
 }
+
+// All returns an iterator over the values in ring r, in forward order,
+// starting with r itself. The behavior of All is undefined if the ring is
+// changed during iteration.
+func (r *Ring[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if r == nil {
+			return
+		}
+
+		if !yield(r.Value) {
+			return
+		}
+
+		for p := r.Next(); p != r; p = p.next {
+			if !yield(p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values in ring r, in backward order,
+// starting with r itself. The behavior of Backward is undefined if the ring is
+// changed during iteration.
+func (r *Ring[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if r == nil {
+			return
+		}
+
+		if !yield(r.Value) {
+			return
+		}
+
+		for p := r.Prev(); p != r; p = p.prev {
+			if !yield(p.Value) {
+				return
+			}
+		}
+	}
+}
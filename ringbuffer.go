@@ -3,6 +3,7 @@ package gbuf
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/zalgonoise/gio"
 )
@@ -405,3 +406,30 @@ func NewRingBuffer[T any](size int) *RingBuffer[T] {
 		items: make([]T, size),
 	}
 }
+
+// All returns an iterator over the index-value pairs of the unread portion of
+// the buffer, from the oldest to the newest item, without consuming them. The
+// behavior of All is undefined if the buffer is modified during iteration.
+func (r *RingBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, n := 0, r.Len(); i < n; i++ {
+			if !yield(i, r.items[(r.read+i)%len(r.items)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index-value pairs of the unread portion
+// of the buffer, from the newest to the oldest item, without consuming them.
+// The behavior of Backward is undefined if the buffer is modified during
+// iteration.
+func (r *RingBuffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.Len() - 1; i >= 0; i-- {
+			if !yield(i, r.items[(r.read+i)%len(r.items)]) {
+				return
+			}
+		}
+	}
+}
@@ -899,3 +899,55 @@ func TestRingBuffer_Cap(t *testing.T) {
 		})
 	}
 }
+
+func TestRingBuffer_All(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input string
+		size  int
+		wants string
+	}{
+		{
+			name:  "Empty",
+			size:  4,
+			wants: "",
+		},
+		{
+			name:  "Partial",
+			input: "abc",
+			size:  8,
+			wants: "abc",
+		},
+		{
+			name:  "Wrapped",
+			input: "abcdefg",
+			size:  5,
+			wants: "cdefg",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			buf := NewRingBuffer[byte](testcase.size)
+
+			_, err := buf.Write([]byte(testcase.input))
+			require.NoError(t, err)
+
+			var forward, backward []byte
+
+			for i, item := range buf.All() {
+				require.Equal(t, testcase.wants[i], item)
+
+				forward = append(forward, item)
+			}
+
+			for i, item := range buf.Backward() {
+				require.Equal(t, testcase.wants[i], item)
+
+				backward = append(backward, item)
+			}
+
+			require.Equal(t, testcase.wants, string(forward))
+			require.Len(t, backward, len(testcase.wants))
+			require.Equal(t, len(testcase.wants), buf.Len())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package gbuf
+
+import "slices"
+
+// Index returns the index of the first instance of sep in s, or -1 if sep is not present in s.
+//
+// Multi-item separators are searched for with the Knuth-Morris-Pratt algorithm, so the
+// complexity is O(len(s) + len(sep)).
+func Index[T comparable](s, sep []T) int {
+	n := len(sep)
+
+	switch {
+	case n == 0:
+		return 0
+	case n == 1:
+		return slices.Index(s, sep[0])
+	case n == len(s):
+		if slices.Equal(s, sep) {
+			return 0
+		}
+
+		return -1
+	case n > len(s):
+		return -1
+	}
+
+	return indexKMP(s, sep, prefixTable(sep))
+}
+
+// Contains reports whether sep is within s.
+func Contains[T comparable](s, sep []T) bool {
+	return Index(s, sep) >= 0
+}
+
+// prefixTable builds the KMP failure function for sep, where table[i] is the length of the
+// longest proper prefix of sep[:i+1] that is also its suffix.
+func prefixTable[T comparable](sep []T) []int {
+	table := make([]int, len(sep))
+
+	for i, k := 1, 0; i < len(sep); i++ {
+		for k > 0 && sep[i] != sep[k] {
+			k = table[k-1]
+		}
+
+		if sep[i] == sep[k] {
+			k++
+		}
+
+		table[i] = k
+	}
+
+	return table
+}
+
+// indexKMP returns the index of the first instance of sep in s, using its prefix table.
+func indexKMP[T comparable](s, sep []T, table []int) int {
+	for i, k := 0, 0; i < len(s); i++ {
+		for k > 0 && s[i] != sep[k] {
+			k = table[k-1]
+		}
+
+		if s[i] == sep[k] {
+			k++
+		}
+
+		if k == len(sep) {
+			return i - len(sep) + 1
+		}
+	}
+
+	return -1
+}
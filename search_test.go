@@ -0,0 +1,99 @@
+package gbuf
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input string
+		sep   string
+		wants int
+	}{
+		{name: "EmptySep", input: "abc", sep: "", wants: 0},
+		{name: "EmptyInput", input: "", sep: "a", wants: -1},
+		{name: "SingleItem", input: "abc", sep: "c", wants: 2},
+		{name: "Equal", input: "abc", sep: "abc", wants: 0},
+		{name: "LongerSep", input: "ab", sep: "abc", wants: -1},
+		{name: "CRLF", input: "GET / HTTP/1.1\r\nHost: x\r\n", sep: "\r\n", wants: 14},
+		{name: "PartialOverlap", input: "aabaabaaab", sep: "aaab", wants: 6},
+		{name: "RepeatedPrefix", input: "abababca", sep: "ababca", wants: 2},
+		{name: "NotFound", input: "abababab", sep: "abba", wants: -1},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			require.Equal(t, testcase.wants, Index([]byte(testcase.input), []byte(testcase.sep)))
+			require.Equal(t, testcase.wants >= 0, Contains([]byte(testcase.input), []byte(testcase.sep)))
+		})
+	}
+}
+
+func TestComparableBuffer_ReadUntil(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input string
+		sep   string
+		wants []string
+		err   error
+	}{
+		{
+			name:  "Frames",
+			input: "one\r\ntwo\r\nthree\r\n",
+			sep:   "\r\n",
+			wants: []string{"one\r\n", "two\r\n", "three\r\n"},
+		},
+		{
+			name:  "Unterminated",
+			input: "one\r\ntwo\r",
+			sep:   "\r\n",
+			wants: []string{"one\r\n", "two\r"},
+			err:   io.EOF,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			b := NewComparableBuffer([]byte(testcase.input))
+			r := NewComparableReader([]byte(testcase.input))
+
+			var bufErr, readerErr error
+
+			for _, wants := range testcase.wants {
+				var line []byte
+
+				require.True(t, b.Contains([]byte(wants)))
+
+				line, bufErr = b.ReadUntil([]byte(testcase.sep))
+				require.Equal(t, wants, string(line))
+
+				line, readerErr = r.ReadUntil([]byte(testcase.sep))
+				require.Equal(t, wants, string(line))
+			}
+
+			require.ErrorIs(t, bufErr, testcase.err)
+			require.ErrorIs(t, readerErr, testcase.err)
+			require.Equal(t, 0, b.Len())
+			require.Equal(t, 0, r.Len())
+		})
+	}
+}
+
+func TestComparableBuffer_ReadUntilEmptySep(t *testing.T) {
+	b := NewComparableBuffer([]byte("abc"))
+	_, err := b.ReadUntil(nil)
+	require.ErrorIs(t, err, ErrBufferInvalidSeparator)
+	require.Equal(t, 3, b.Len())
+
+	p := AsComparablePeekBuffer(NewPeekBuffer([]byte("abc")))
+	_, err = p.ReadUntil([]byte{})
+	require.ErrorIs(t, err, ErrBufferInvalidSeparator)
+	_, err = p.PeekUntil(nil)
+	require.ErrorIs(t, err, ErrPeekBufferInvalidSeparator)
+	require.Equal(t, 3, p.Len())
+
+	r := NewComparableReader([]byte("abc"))
+	_, err = r.ReadUntil(nil)
+	require.ErrorIs(t, err, ErrReaderInvalidSeparator)
+	require.Equal(t, 3, r.Len())
+}
@@ -0,0 +1,110 @@
+package gbuf
+
+import "slices"
+
+// TopK keeps the k largest items (according to a less function) offered to
+// it, out of a stream of any length, in O(k) memory. It is a bounded min-heap
+// whose root is the smallest of the items kept, so that each item is either
+// discarded or replaces the root in O(log k).
+//
+// TopK implements gio.Writer, so it can be the target of a Buffer's or a
+// RingBuffer's WriteTo method.
+//
+// The zero value is not usable; create one with NewTopK.
+type TopK[T any] struct {
+	h lessHeap[T]
+	k int
+}
+
+// NewTopK creates a TopK keeping the k largest items, as ordered by less,
+// which reports whether a is smaller than b. If k is not positive, no items
+// are kept.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	if k < 0 {
+		k = 0
+	}
+
+	return &TopK[T]{
+		h: lessHeap[T]{
+			items: make([]T, 0, k),
+			less:  less,
+		},
+		k: k,
+	}
+}
+
+// Len returns the number of items kept, which is at most k.
+func (t *TopK[T]) Len() int { return len(t.h.items) }
+
+// Offer adds item to the set of the k largest items, if it is larger than the
+// smallest of them, or if fewer than k items are kept. It returns whether item
+// was kept.
+// The complexity is O(log k).
+func (t *TopK[T]) Offer(item T) bool {
+	if len(t.h.items) < t.k {
+		Push[T](&t.h, item)
+
+		return true
+	}
+
+	if t.k == 0 || !t.h.less(t.h.items[0], item) {
+		return false
+	}
+
+	t.h.items[0] = item
+	Fix[T](&t.h, 0)
+
+	return true
+}
+
+// Write implements the gio.Writer interface, offering each item in p.
+// It always returns len(p), nil.
+func (t *TopK[T]) Write(p []T) (n int, err error) {
+	for i := range p {
+		t.Offer(p[i])
+	}
+
+	return len(p), nil
+}
+
+// WriteItem offers a single item. It always returns nil.
+func (t *TopK[T]) WriteItem(item T) error {
+	t.Offer(item)
+
+	return nil
+}
+
+// Min returns the smallest of the items kept, which is the threshold a new item
+// must exceed to be kept once k items are. It returns false if no items are kept.
+func (t *TopK[T]) Min() (T, bool) {
+	if len(t.h.items) == 0 {
+		return *new(T), false
+	}
+
+	return t.h.items[0], true
+}
+
+// Result returns a new slice with the items kept, sorted from the largest to the
+// smallest. The order of equal items is unspecified.
+func (t *TopK[T]) Result() []T {
+	result := slices.Clone(t.h.items)
+
+	slices.SortFunc(result, func(a, b T) int {
+		switch {
+		case t.h.less(b, a):
+			return -1
+		case t.h.less(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return result
+}
+
+// Reset removes all items kept, retaining the underlying storage.
+func (t *TopK[T]) Reset() {
+	clear(t.h.items)
+	t.h.items = t.h.items[:0]
+}
@@ -0,0 +1,116 @@
+package gbuf
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/gio"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestTopK(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		k     int
+		input []int
+		wants []int
+	}{
+		{
+			name:  "Empty",
+			k:     3,
+			wants: []int{},
+		},
+		{
+			name:  "FewerThanK",
+			k:     5,
+			input: []int{4, 1, 3},
+			wants: []int{4, 3, 1},
+		},
+		{
+			name:  "MoreThanK",
+			k:     3,
+			input: []int{5, 1, 9, 3, 7, 2, 8},
+			wants: []int{9, 8, 7},
+		},
+		{
+			name:  "Duplicates",
+			k:     3,
+			input: []int{5, 5, 1, 5, 5},
+			wants: []int{5, 5, 5},
+		},
+		{
+			name:  "ZeroK",
+			k:     0,
+			input: []int{1, 2, 3},
+			wants: []int{},
+		},
+		{
+			name:  "NegativeK",
+			k:     -1,
+			input: []int{1, 2, 3},
+			wants: []int{},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			top := NewTopK(testcase.k, intLess)
+
+			for _, item := range testcase.input {
+				top.Offer(item)
+			}
+
+			require.Equal(t, testcase.wants, top.Result())
+			require.Equal(t, len(testcase.wants), top.Len())
+		})
+	}
+}
+
+func TestTopK_Random(t *testing.T) {
+	const k = 10
+
+	input := rand.Perm(1000)
+	top := NewTopK(k, intLess)
+
+	n, err := top.Write(input)
+	require.NoError(t, err)
+	require.Equal(t, len(input), n)
+
+	slices.Sort(input)
+	slices.Reverse(input)
+
+	require.Equal(t, input[:k], top.Result())
+
+	smallest, ok := top.Min()
+	require.True(t, ok)
+	require.Equal(t, input[k-1], smallest)
+	require.False(t, top.Offer(input[k]))
+
+	top.Reset()
+	require.Zero(t, top.Len())
+
+	_, ok = top.Min()
+	require.False(t, ok)
+}
+
+func TestTopK_WriteTo(t *testing.T) {
+	var _ gio.Writer[int] = (*TopK[int])(nil)
+
+	buf := NewBuffer([]int{3, 14, 15, 92, 65, 35, 89, 79})
+	top := NewTopK(3, intLess)
+
+	n, err := buf.WriteTo(top)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), n)
+	require.Equal(t, []int{92, 89, 79}, top.Result())
+
+	ring := NewRingBuffer[int](4)
+	_, err = ring.Write([]int{1, 2, 3, 4, 5, 6})
+	require.NoError(t, err)
+
+	top = NewTopK(2, intLess)
+	_, err = ring.WriteTo(top)
+	require.NoError(t, err)
+	require.Equal(t, []int{6, 5}, top.Result())
+}